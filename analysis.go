@@ -0,0 +1,110 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// MaxCrackTime is the longest duration returned by CrackTime, estimates which don't fit in a
+// time.Duration (roughly 292 years) are capped to it.
+const MaxCrackTime = time.Duration(math.MaxInt64)
+
+// CrackTime estimates the average time an offline brute force attack making guessesPerSecond
+// guesses would take to find the password.  The character space is inferred from the default
+// classes present in the password, with every distinct character outside of them adding one to
+// the space.  This is a rough estimate for strength meters and ignores dictionary attacks.
+func CrackTime(password string, guessesPerSecond float64) time.Duration {
+	runes := []rune(password)
+	if len(runes) == 0 {
+		return 0
+	}
+	if guessesPerSecond <= 0 {
+		return MaxCrackTime
+	}
+
+	// On average half the space has to be searched before the password is found.
+	bits := float64(len(runes))*math.Log2(float64(characterSpace(runes))) - 1
+	seconds := bits - math.Log2(guessesPerSecond)
+	nanoseconds := seconds + math.Log2(float64(time.Second))
+	if nanoseconds >= 63 {
+		return MaxCrackTime
+	}
+	return time.Duration(math.Exp2(nanoseconds))
+}
+
+// CountClasses counts the characters of the password in each of the default classes, see
+// LowerLetters, UpperLetters, Digits, and Symbols.  Characters outside of them, e.g. custom
+// symbols or letters from other scripts, are counted as other.
+func CountClasses(password string) (lower, upper, digits, symbols, other int) {
+	for _, r := range password {
+		class, ok := classOf(r)
+		switch {
+		case !ok:
+			other++
+		case class == ClassLower:
+			lower++
+		case class == ClassUpper:
+			upper++
+		case class == ClassDigit:
+			digits++
+		case class == ClassSymbol:
+			symbols++
+		}
+	}
+	return
+}
+
+// characterSpace returns the size of the character space the runes were likely drawn from.
+func characterSpace(runes []rune) int {
+	present := map[CharClass]bool{}
+	other := map[rune]bool{}
+	for _, r := range runes {
+		if class, ok := classOf(r); ok {
+			present[class] = true
+		} else {
+			other[r] = true
+		}
+	}
+
+	space := len(other)
+	for class := range present {
+		space += len([]rune(defaultPool(class)))
+	}
+	return space
+}
+
+// classOf returns the default class r belongs to, if any.
+func classOf(r rune) (CharClass, bool) {
+	for _, class := range []CharClass{ClassLower, ClassUpper, ClassDigit, ClassSymbol} {
+		if strings.ContainsRune(defaultPool(class), r) {
+			return class, true
+		}
+	}
+	return 0, false
+}
+
+// defaultPool returns the default characters of the class.
+func defaultPool(class CharClass) string {
+	switch class {
+	case ClassLower:
+		return LowerLetters
+	case ClassUpper:
+		return UpperLetters
+	case ClassDigit:
+		return Digits
+	case ClassSymbol:
+		return Symbols
+	}
+	return ""
+}
@@ -0,0 +1,67 @@
+package passwordgen
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCrackTime(t *testing.T) {
+	t.Parallel()
+
+	t.Run("longer_mixed_is_stronger", func(t *testing.T) {
+		t.Parallel()
+		short := CrackTime("abcd", 1e10)
+		long := CrackTime("Xk9#mP2$", 1e10)
+		if long <= short*1000000 {
+			t.Errorf("expected %s to be far longer than %s", long, short)
+		}
+	})
+
+	t.Run("estimate", func(t *testing.T) {
+		t.Parallel()
+		// 10^6 digit combinations, half searched on average at 1000 guesses per second.
+		if actual := CrackTime("123456", 1000); actual < 499*time.Second || actual > 501*time.Second {
+			t.Errorf("expected roughly 500s, actual: %s", actual)
+		}
+	})
+
+	t.Run("capped", func(t *testing.T) {
+		t.Parallel()
+		if actual := CrackTime("Xk9#mP2$qL7!vR4&Xk9#mP2$qL7!vR4&", 1e12); actual != MaxCrackTime {
+			t.Errorf("expected: %s, actual: %s", MaxCrackTime, actual)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+		if actual := CrackTime("", 1e10); actual != 0 {
+			t.Errorf("expected: 0, actual: %s", actual)
+		}
+	})
+}
+
+func TestCountClasses(t *testing.T) {
+	t.Parallel()
+
+	t.Run("known", func(t *testing.T) {
+		t.Parallel()
+		lower, upper, digits, symbols, other := CountClasses("abC12#$%é🔥")
+		actual := [5]int{lower, upper, digits, symbols, other}
+		if expected := [5]int{2, 1, 2, 3, 2}; actual != expected {
+			t.Errorf("expected: %v, actual: %v", expected, actual)
+		}
+	})
+
+	t.Run("generated", func(t *testing.T) {
+		t.Parallel()
+		pass, err := NewGenerator().ExactLower(3).ExactUpper(4).ExactDigits(5).ExactSymbols(6).Generate(18)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		lower, upper, digits, symbols, other := CountClasses(pass)
+		actual := [5]int{lower, upper, digits, symbols, other}
+		if expected := [5]int{3, 4, 5, 6, 0}; actual != expected {
+			t.Errorf("expected: %v, actual: %v for %s", expected, actual, pass)
+		}
+	})
+}
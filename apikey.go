@@ -0,0 +1,226 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import (
+	"crypto/rand"
+	"errors"
+	"hash/crc32"
+	"io"
+	"strings"
+)
+
+// Base62Alphabet is the digits and letters of both cases, the default alphabet of the random
+// segment of API keys.
+const Base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+var (
+	// ErrInvalidAlphabet is the error returned when an API key alphabet has fewer than 2
+	// characters or repeats a character
+	ErrInvalidAlphabet = errors.New("alphabet must have at least 2 distinct characters")
+
+	// ErrInvalidKeyLength is the error returned when an API key is requested or verified with a
+	// non-positive length
+	ErrInvalidKeyLength = errors.New("length of the random segment must be positive")
+
+	// ErrInvalidAPIKey is the error returned when verifying a key which doesn't have the
+	// generator's prefix or length, contains characters outside of its alphabet, or fails its
+	// checksum
+	ErrInvalidAPIKey = errors.New("invalid API key")
+)
+
+// Checksum is the check segment appended to API keys, which lets secret scanners tell keys
+// apart from random strings which happen to have the same prefix.
+type Checksum int
+
+const (
+	// ChecksumNone appends no check segment.
+	ChecksumNone Checksum = iota
+
+	// ChecksumCRC32 appends the CRC-32 of the prefix and random segment, encoded with the
+	// alphabet using as many characters as 32 bits need, e.g. 6 for Base62Alphabet.  This is
+	// the scheme of GitHub tokens.
+	ChecksumCRC32
+
+	// ChecksumLuhn appends a single Luhn mod N check character computed over the random
+	// segment, which catches every single character error and most transpositions.
+	ChecksumLuhn
+)
+
+// APIKeyGenerator is used to generate API keys made of a fixed prefix, a random segment, and
+// an optional check segment, e.g. pk_live_4eC39HqLyjWDarjtT1zdp7dc.
+type APIKeyGenerator struct {
+	prefix   string
+	alphabet []string
+	checksum Checksum
+
+	random io.Reader
+}
+
+// NewAPIKeyGenerator returns a new API key generator which starts keys with prefix and draws
+// the random segment from Base62Alphabet without a check segment.
+func NewAPIKeyGenerator(prefix string) *APIKeyGenerator {
+	return &APIKeyGenerator{
+		prefix:   prefix,
+		alphabet: split(Base62Alphabet),
+	}
+}
+
+// WithAlphabet will draw the random and check segments from the characters of alphabet.
+// Generate and Verify return ErrInvalidAlphabet if it has fewer than 2 distinct characters or
+// repeats a character.
+func (a *APIKeyGenerator) WithAlphabet(alphabet string) *APIKeyGenerator {
+	a.alphabet = split(alphabet)
+	return a
+}
+
+// WithChecksum will append the check segment of checksum to keys.
+func (a *APIKeyGenerator) WithChecksum(checksum Checksum) *APIKeyGenerator {
+	a.checksum = checksum
+	return a
+}
+
+// WithRand will draw the random segment from r instead of crypto/rand.  This is meant for
+// deterministic tests; keys from a non-cryptographic source must not be used as secrets.
+func (a *APIKeyGenerator) WithRand(r io.Reader) *APIKeyGenerator {
+	a.random = r
+	return a
+}
+
+// Generate will generate an API key with a random segment of length characters.  The prefix
+// and check segment are not counted towards length.
+func (a *APIKeyGenerator) Generate(length int) (string, error) {
+	if length <= 0 {
+		return "", ErrInvalidKeyLength
+	}
+	if err := a.validateAlphabet(); err != nil {
+		return "", err
+	}
+
+	buffer := strings.Builder{}
+	for i := 0; i < length; i++ {
+		elm, err := randomElement(a.reader(), a.alphabet)
+		if err != nil {
+			return "", err
+		}
+		buffer.WriteString(elm)
+	}
+	random := buffer.String()
+	return a.prefix + random + a.check(random), nil
+}
+
+// Verify returns ErrInvalidAPIKey if key could not have been generated by the generator with
+// a random segment of length characters, or ErrInvalidKeyLength if length isn't positive.
+// Without a checksum only the prefix, length, and alphabet are checked.
+func (a *APIKeyGenerator) Verify(key string, length int) error {
+	if length <= 0 {
+		return ErrInvalidKeyLength
+	}
+	if err := a.validateAlphabet(); err != nil {
+		return err
+	}
+	rest, ok := strings.CutPrefix(key, a.prefix)
+	if !ok {
+		return ErrInvalidAPIKey
+	}
+	elements := split(rest)
+	if len(elements) != length+a.checkLength() {
+		return ErrInvalidAPIKey
+	}
+	for _, elm := range elements {
+		if a.index(elm) < 0 {
+			return ErrInvalidAPIKey
+		}
+	}
+	random := strings.Join(elements[:length], "")
+	if strings.Join(elements[length:], "") != a.check(random) {
+		return ErrInvalidAPIKey
+	}
+	return nil
+}
+
+// check returns the check segment of a key with the random segment random.
+func (a *APIKeyGenerator) check(random string) string {
+	switch a.checksum {
+	case ChecksumCRC32:
+		sum := uint64(crc32.ChecksumIEEE([]byte(a.prefix + random)))
+		digits := make([]string, a.checkLength())
+		for i := len(digits) - 1; i >= 0; i-- {
+			digits[i] = a.alphabet[sum%uint64(len(a.alphabet))]
+			sum /= uint64(len(a.alphabet))
+		}
+		return strings.Join(digits, "")
+	case ChecksumLuhn:
+		return a.alphabet[a.luhn(split(random))]
+	}
+	return ""
+}
+
+// checkLength returns the number of characters of the check segment.
+func (a *APIKeyGenerator) checkLength() int {
+	switch a.checksum {
+	case ChecksumCRC32:
+		n := 0
+		for limit := uint64(1); limit <= 1<<32-1; limit *= uint64(len(a.alphabet)) {
+			n++
+		}
+		return n
+	case ChecksumLuhn:
+		return 1
+	}
+	return 0
+}
+
+// luhn returns the index in the alphabet of the Luhn mod N check character of elements.
+func (a *APIKeyGenerator) luhn(elements []string) int {
+	n := len(a.alphabet)
+	sum := 0
+	factor := 2
+	for i := len(elements) - 1; i >= 0; i-- {
+		addend := factor * a.index(elements[i])
+		sum += addend/n + addend%n
+		factor = 3 - factor
+	}
+	return (n - sum%n) % n
+}
+
+// index returns the position of elm in the alphabet, or -1 if it isn't in it.
+func (a *APIKeyGenerator) index(elm string) int {
+	for i, candidate := range a.alphabet {
+		if candidate == elm {
+			return i
+		}
+	}
+	return -1
+}
+
+// validateAlphabet returns ErrInvalidAlphabet if the alphabet can't encode keys unambiguously.
+func (a *APIKeyGenerator) validateAlphabet() error {
+	if len(a.alphabet) < 2 {
+		return ErrInvalidAlphabet
+	}
+	seen := make(map[string]bool, len(a.alphabet))
+	for _, elm := range a.alphabet {
+		if seen[elm] {
+			return ErrInvalidAlphabet
+		}
+		seen[elm] = true
+	}
+	return nil
+}
+
+// reader returns the source of randomness of the generator.
+func (a *APIKeyGenerator) reader() io.Reader {
+	if a.random == nil {
+		return rand.Reader
+	}
+	return a.random
+}
@@ -0,0 +1,112 @@
+package passwordgen
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestAPIKeyGenerator(t *testing.T) {
+	t.Parallel()
+
+	t.Run("prefix", func(t *testing.T) {
+		t.Parallel()
+		key, err := NewAPIKeyGenerator("pk_live_").Generate(24)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if !regexp.MustCompile("^pk_live_[0-9A-Za-z]{24}$").MatchString(key) {
+			t.Errorf("key %s is not a prefixed base62 key", key)
+		}
+	})
+
+	t.Run("alphabet", func(t *testing.T) {
+		t.Parallel()
+		key, err := NewAPIKeyGenerator("k-").WithAlphabet("ab").Generate(16)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if !regexp.MustCompile("^k-[ab]{16}$").MatchString(key) {
+			t.Errorf("key %s contains characters outside of the alphabet", key)
+		}
+	})
+
+	for _, tc := range []struct {
+		name     string
+		checksum Checksum
+		length   int
+	}{
+		{"none", ChecksumNone, 0},
+		{"crc32", ChecksumCRC32, 6},
+		{"luhn", ChecksumLuhn, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			gen := NewAPIKeyGenerator("ghp_").WithChecksum(tc.checksum)
+			key, err := gen.Generate(30)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if expected := len("ghp_") + 30 + tc.length; len(key) != expected {
+				t.Errorf("expected: %d, actual: %d", expected, len(key))
+			}
+			if err := gen.Verify(key, 30); err != nil {
+				t.Errorf("expected no error, received %q", err)
+			}
+			if err := gen.Verify("xyz_"+strings.TrimPrefix(key, "ghp_"), 30); err != ErrInvalidAPIKey {
+				t.Errorf("expected: %q, actual: %q", ErrInvalidAPIKey, err)
+			}
+			if err := gen.Verify(key+"0", 30); err != ErrInvalidAPIKey {
+				t.Errorf("expected: %q, actual: %q", ErrInvalidAPIKey, err)
+			}
+		})
+	}
+
+	t.Run("detects_typos", func(t *testing.T) {
+		t.Parallel()
+		for _, checksum := range []Checksum{ChecksumCRC32, ChecksumLuhn} {
+			gen := NewAPIKeyGenerator("ghp_").WithChecksum(checksum)
+			key, err := gen.Generate(30)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			// Change a single character of the random segment.
+			b := []byte(key)
+			if b[10] == 'a' {
+				b[10] = 'b'
+			} else {
+				b[10] = 'a'
+			}
+			if err := gen.Verify(string(b), 30); err != ErrInvalidAPIKey {
+				t.Errorf("expected: %q, actual: %q", ErrInvalidAPIKey, err)
+			}
+		}
+	})
+
+	t.Run("known_luhn", func(t *testing.T) {
+		t.Parallel()
+		// The Luhn mod 10 check digit of 7992739871 is 3.
+		gen := NewAPIKeyGenerator("").WithAlphabet("0123456789").WithChecksum(ChecksumLuhn)
+		if err := gen.Verify("79927398713", 10); err != nil {
+			t.Errorf("expected no error, received %q", err)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+		if _, err := NewAPIKeyGenerator("").Generate(0); err != ErrInvalidKeyLength {
+			t.Errorf("expected: %q, actual: %q", ErrInvalidKeyLength, err)
+		}
+		for _, length := range []int{0, -1} {
+			gen := NewAPIKeyGenerator("pk_").WithChecksum(ChecksumLuhn)
+			if err := gen.Verify("pk_", length); err != ErrInvalidKeyLength {
+				t.Errorf("expected: %q, actual: %q", ErrInvalidKeyLength, err)
+			}
+		}
+		for _, alphabet := range []string{"", "a", "aba"} {
+			if _, err := NewAPIKeyGenerator("").WithAlphabet(alphabet).Generate(8); err != ErrInvalidAlphabet {
+				t.Errorf("expected: %q, actual: %q", ErrInvalidAlphabet, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,213 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// ErrInsufficientUnique is the error returned when a set of batches does not
+	// contain enough unique passwords to satisfy the requested count
+	ErrInsufficientUnique = errors.New("not enough unique passwords to satisfy the requested count")
+
+	// ErrInvalidCount is the error returned when a batch of passwords is
+	// requested with a non-positive count
+	ErrInvalidCount = errors.New("number of passwords must be positive")
+)
+
+// GenerateN will generate count passwords at the specified length as configured.  If any
+// password fails to generate no passwords are returned.
+func (g *Generator) GenerateN(count, length int) ([]string, error) {
+	if count <= 0 {
+		return nil, ErrInvalidCount
+	}
+	passwords := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		pass, err := g.Generate(length)
+		if err != nil {
+			return nil, err
+		}
+		passwords = append(passwords, pass)
+	}
+	return passwords, nil
+}
+
+// GenerateUniqueN behaves like GenerateN but guarantees the passwords are unique, duplicates
+// are regenerated.  If the maximum number of attempts in a row only produce duplicates, as
+// when the generator can't produce count distinct passwords, ErrInsufficientUnique is
+// returned.
+func (g *Generator) GenerateUniqueN(count, length int) ([]string, error) {
+	if count <= 0 {
+		return nil, ErrInvalidCount
+	}
+	passwords := make([]string, 0, count)
+	seen := make(map[string]struct{}, count)
+	for duplicates := 0; len(passwords) < count; {
+		pass, err := g.Generate(length)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[pass]; ok {
+			if duplicates++; duplicates >= g.attempts() {
+				return nil, ErrInsufficientUnique
+			}
+			continue
+		}
+		duplicates = 0
+		seen[pass] = struct{}{}
+		passwords = append(passwords, pass)
+	}
+	return passwords, nil
+}
+
+// MergeUnique concatenates the given batches, keeping the first occurrence of
+// each password and dropping any duplicates. Order is otherwise preserved.
+func MergeUnique(batches ...[]string) ([]string, error) {
+	return MergeUniqueN(0, batches...)
+}
+
+// MergeUniqueN behaves like MergeUnique but returns ErrInsufficientUnique when
+// fewer than n unique passwords remain after removing duplicates.
+func MergeUniqueN(n int, batches ...[]string) ([]string, error) {
+	total := 0
+	for _, batch := range batches {
+		total += len(batch)
+	}
+
+	seen := make(map[string]struct{}, total)
+	merged := make([]string, 0, total)
+	for _, batch := range batches {
+		for _, pass := range batch {
+			if _, ok := seen[pass]; ok {
+				continue
+			}
+			seen[pass] = struct{}{}
+			merged = append(merged, pass)
+		}
+	}
+
+	if len(merged) < n {
+		return nil, ErrInsufficientUnique
+	}
+	return merged, nil
+}
+
+// GenerateNConcurrent behaves like GenerateN but distributes the generation across workers
+// goroutines, for very large batches on multi-core machines.  The passwords are returned in
+// no particular order relative to when they were generated.  The first error from any worker
+// stops the others and is returned.  Values of workers less than 1 use runtime.GOMAXPROCS.
+//
+// The workers share the generator, so the source of randomness set with WithRand must be safe
+// for concurrent use, which crypto/rand is.
+func (g *Generator) GenerateNConcurrent(length, count, workers int) ([]string, error) {
+	if count <= 0 {
+		return nil, ErrInvalidCount
+	}
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, count)
+
+	passwords := make([]string, count)
+	var (
+		next     atomic.Int64
+		failed   atomic.Bool
+		errOnce  sync.Once
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for !failed.Load() {
+				i := int(next.Add(1) - 1)
+				if i >= count {
+					return
+				}
+				pass, err := g.Generate(length)
+				if err != nil {
+					errOnce.Do(func() { firstErr = err })
+					failed.Store(true)
+					return
+				}
+				passwords[i] = pass
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return passwords, nil
+}
+
+// GenerateChannel will generate passwords at the specified length as configured until ctx is
+// cancelled, sending them on the returned password channel.  If generation fails the error is
+// sent on the error channel and generation stops.  Both channels are closed once generation
+// stops, the goroutine generating passwords exits as soon as ctx is cancelled.
+func (g *Generator) GenerateChannel(ctx context.Context, length int) (<-chan string, <-chan error) {
+	passwords := make(chan string)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(passwords)
+		defer close(errs)
+		for {
+			if ctx.Err() != nil {
+				return
+			}
+			pass, err := g.Generate(length)
+			if err != nil {
+				errs <- err
+				return
+			}
+			select {
+			case passwords <- pass:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return passwords, errs
+}
+
+// Stream returns an iterator over passwords generated at the specified length as configured,
+// which yields passwords until ctx is cancelled or the loop is stopped.  Unlike
+// GenerateChannel no goroutine is started, each password is generated as the loop asks for
+// it.  If generation fails the error is yielded with an empty password and iteration stops.
+//
+//	for pass, err := range gen.Stream(ctx, 16) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (g *Generator) Stream(ctx context.Context, length int) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		for ctx.Err() == nil {
+			pass, err := g.Generate(length)
+			if err != nil {
+				yield("", err)
+				return
+			}
+			if !yield(pass, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package passwordgen
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMergeUnique(t *testing.T) {
+	t.Parallel()
+
+	t.Run("overlapping_batches", func(t *testing.T) {
+		t.Parallel()
+		merged, err := MergeUnique([]string{"a", "b", "c"}, []string{"c", "d", "a"}, []string{"e", "e"})
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		expected := []string{"a", "b", "c", "d", "e"}
+		if !reflect.DeepEqual(merged, expected) {
+			t.Errorf("expected: %v, actual: %v", expected, merged)
+		}
+		seen := map[string]bool{}
+		for _, pass := range merged {
+			if seen[pass] {
+				t.Errorf("password %s appears more than once", pass)
+			}
+			seen[pass] = true
+		}
+	})
+
+	t.Run("generated_batches", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithDigits()
+		var first, second []string
+		for i := 0; i < 50; i++ {
+			a, err := gen.Generate(1)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			b, err := gen.Generate(1)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			first, second = append(first, a), append(second, b)
+		}
+		merged, err := MergeUnique(first, second)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		// Only ten single digit passwords exist.
+		if len(merged) > 10 {
+			t.Errorf("expected at most 10 unique passwords, received %d", len(merged))
+		}
+	})
+
+	t.Run("insufficient_unique", func(t *testing.T) {
+		t.Parallel()
+		if _, err := MergeUniqueN(3, []string{"a", "b"}, []string{"b", "a"}); err != ErrInsufficientUnique {
+			t.Errorf("expected: %q, actual: %q", ErrInsufficientUnique, err)
+		}
+		if _, err := MergeUniqueN(2, []string{"a", "b"}, []string{"b", "a"}); err != nil {
+			t.Errorf("expected no error, received %q", err)
+		}
+	})
+}
+
+func TestGenerator_GenerateChannel(t *testing.T) {
+	t.Parallel()
+
+	t.Run("cancel", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		passwords, errs := NewGenerator().WithLower().WithDigits().GenerateChannel(ctx, 12)
+		for i := 0; i < 3; i++ {
+			if pass := <-passwords; len(pass) != 12 {
+				t.Errorf("Expected password %s to be 12 characters long", pass)
+			}
+		}
+		cancel()
+
+		timeout := time.After(time.Second)
+		for passwords != nil || errs != nil {
+			select {
+			case _, ok := <-passwords:
+				if !ok {
+					passwords = nil
+				}
+			case err, ok := <-errs:
+				if ok {
+					t.Errorf("expected no error, received %q", err)
+				}
+				errs = nil
+			case <-timeout:
+				t.Fatal("expected channels to be closed after cancelling")
+			}
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		passwords, errs := NewGenerator().GenerateChannel(context.Background(), 12)
+		if err := <-errs; !errors.Is(err, ErrNoCharactersSpecified) {
+			t.Errorf("expected: %q, actual: %q", ErrNoCharactersSpecified, err)
+		}
+		if _, ok := <-passwords; ok {
+			t.Error("expected the password channel to be closed")
+		}
+	})
+}
+
+func TestGenerator_GenerateN(t *testing.T) {
+	t.Parallel()
+
+	t.Run("count", func(t *testing.T) {
+		t.Parallel()
+		passwords, err := NewGenerator().WithLower().GenerateN(10, 8)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if len(passwords) != 10 {
+			t.Errorf("expected: 10 passwords, actual: %d", len(passwords))
+		}
+	})
+
+	t.Run("invalid_count", func(t *testing.T) {
+		t.Parallel()
+		if _, err := NewGenerator().WithLower().GenerateN(0, 8); err != ErrInvalidCount {
+			t.Errorf("expected: %q, actual: %q", ErrInvalidCount, err)
+		}
+	})
+}
+
+func TestGenerator_GenerateUniqueN(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unique", func(t *testing.T) {
+		t.Parallel()
+		// 100 passwords of 2 digits are all the passwords there are.
+		passwords, err := NewGenerator().WithDigits().WithMaxAttempts(10000).GenerateUniqueN(100, 2)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if len(passwords) != 100 {
+			t.Errorf("expected: 100 passwords, actual: %d", len(passwords))
+		}
+		if unique, _ := MergeUnique(passwords); len(unique) != len(passwords) {
+			t.Errorf("expected: %d unique passwords, actual: %d", len(passwords), len(unique))
+		}
+	})
+
+	t.Run("insufficient", func(t *testing.T) {
+		t.Parallel()
+		if _, err := NewGenerator().WithDigits().GenerateUniqueN(11, 1); !errors.Is(err, ErrInsufficientUnique) {
+			t.Errorf("expected: %q, actual: %q", ErrInsufficientUnique, err)
+		}
+	})
+
+	t.Run("invalid_count", func(t *testing.T) {
+		t.Parallel()
+		if _, err := NewGenerator().WithLower().GenerateUniqueN(0, 8); !errors.Is(err, ErrInvalidCount) {
+			t.Errorf("expected: %q, actual: %q", ErrInvalidCount, err)
+		}
+	})
+}
+
+func TestGenerator_GenerateNConcurrent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("count", func(t *testing.T) {
+		t.Parallel()
+		passwords, err := NewGenerator().WithLower().WithDigits().GenerateNConcurrent(12, 1000, 8)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if len(passwords) != 1000 {
+			t.Errorf("expected: 1000 passwords, actual: %d", len(passwords))
+		}
+		for _, pass := range passwords {
+			if len(pass) != 12 {
+				t.Fatalf("expected password %q to be 12 characters long", pass)
+			}
+		}
+		if unique, _ := MergeUnique(passwords); len(unique) != len(passwords) {
+			t.Errorf("expected every password to be unique, %d duplicates", len(passwords)-len(unique))
+		}
+	})
+
+	t.Run("default_workers", func(t *testing.T) {
+		t.Parallel()
+		passwords, err := NewGenerator().WithLower().GenerateNConcurrent(8, 10, 0)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if len(passwords) != 10 {
+			t.Errorf("expected: 10 passwords, actual: %d", len(passwords))
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		if _, err := NewGenerator().GenerateNConcurrent(8, 1000, 4); !errors.Is(err, ErrNoCharactersSpecified) {
+			t.Errorf("expected: %q, actual: %q", ErrNoCharactersSpecified, err)
+		}
+		if _, err := NewGenerator().WithLower().GenerateNConcurrent(8, 0, 4); err != ErrInvalidCount {
+			t.Errorf("expected: %q, actual: %q", ErrInvalidCount, err)
+		}
+	})
+}
+
+func BenchmarkGenerator_GenerateN(b *testing.B) {
+	gen := NewGenerator().WithLower().WithUpper().WithDigits().WithSymbols()
+	for i := 0; i < b.N; i++ {
+		if _, err := gen.GenerateN(100000, 16); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGenerator_GenerateNConcurrent(b *testing.B) {
+	gen := NewGenerator().WithLower().WithUpper().WithDigits().WithSymbols()
+	for i := 0; i < b.N; i++ {
+		if _, err := gen.GenerateNConcurrent(16, 100000, 0); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestGenerator_Stream(t *testing.T) {
+	t.Parallel()
+
+	t.Run("break", func(t *testing.T) {
+		t.Parallel()
+		count := 0
+		for pass, err := range NewGenerator().WithLower().WithDigits().Stream(context.Background(), 12) {
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if len(pass) != 12 {
+				t.Errorf("Expected password %s to be 12 characters long", pass)
+			}
+			if count++; count == 5 {
+				break
+			}
+		}
+		if count != 5 {
+			t.Errorf("expected: 5 passwords, actual: %d", count)
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		count := 0
+		for _, err := range NewGenerator().WithLower().Stream(ctx, 12) {
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if count++; count == 3 {
+				cancel()
+			}
+		}
+		if count != 3 {
+			t.Errorf("expected: 3 passwords, actual: %d", count)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		count := 0
+		for pass, err := range NewGenerator().Stream(context.Background(), 12) {
+			count++
+			if pass != "" || !errors.Is(err, ErrNoCharactersSpecified) {
+				t.Errorf("expected: %q, actual: %q", ErrNoCharactersSpecified, err)
+			}
+		}
+		if count != 1 {
+			t.Errorf("expected iteration to stop after the error, received %d values", count)
+		}
+	})
+}
@@ -0,0 +1,36 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import "strings"
+
+// BreachChecker reports whether a password is known to have been exposed in a data breach.
+// The breachcheck subpackage provides one backed by the Have I Been Pwned range API.
+type BreachChecker interface {
+	Breached(password string) (bool, error)
+}
+
+// WithBreachCheck will have the generator check every password it generates with checker and
+// regenerate it if it has been breached.  An error from checker is returned by Generate, and
+// the generator gives up with ErrMaxAttemptsExceeded if every attempt was breached.
+func (g *Generator) WithBreachCheck(checker BreachChecker) *Generator {
+	g.breachChecker = checker
+	return g
+}
+
+// breached returns whether the elements of a password have been breached according to the
+// generator's BreachChecker.
+func (g *Generator) breached(elements []string) (bool, error) {
+	if g.breachChecker == nil {
+		return false, nil
+	}
+	return g.breachChecker.Breached(strings.Join(elements, ""))
+}
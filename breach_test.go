@@ -0,0 +1,67 @@
+package passwordgen
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+// fakeBreachChecker reports the passwords in breached as breached and records every check.
+// onCheck, if set, is called with every checked password.
+type fakeBreachChecker struct {
+	mu       sync.Mutex
+	breached map[string]bool
+	checked  []string
+	err      error
+	onCheck  func(password string)
+}
+
+func (f *fakeBreachChecker) Breached(password string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.checked = append(f.checked, password)
+	if f.onCheck != nil {
+		f.onCheck(password)
+	}
+	return f.breached[password], f.err
+}
+
+func TestGenerator_WithBreachCheck(t *testing.T) {
+	t.Parallel()
+
+	t.Run("regenerates", func(t *testing.T) {
+		t.Parallel()
+		checker := &fakeBreachChecker{breached: map[string]bool{}}
+		for d := '0'; d <= '8'; d++ {
+			checker.breached[string(d)] = true
+		}
+		pass, err := NewGenerator().WithDigits().WithMaxAttempts(1000).WithBreachCheck(checker).Generate(1)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if pass != "9" {
+			t.Errorf("expected: %q, actual: %q", "9", pass)
+		}
+		if checker.checked[len(checker.checked)-1] != pass {
+			t.Errorf("expected: %q, actual: %q", pass, checker.checked[len(checker.checked)-1])
+		}
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		t.Parallel()
+		checker := &fakeBreachChecker{breached: map[string]bool{"0": true, "1": true}}
+		_, err := NewGenerator().WithDigits().ExcludeCharacters("23456789").WithBreachCheck(checker).Generate(1)
+		if !errors.Is(err, ErrMaxAttemptsExceeded) {
+			t.Errorf("expected: %q, actual: %q", ErrMaxAttemptsExceeded, err)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		failure := errors.New("service unavailable")
+		checker := &fakeBreachChecker{err: failure}
+		if _, err := NewGenerator().WithLower().WithBreachCheck(checker).Generate(8); !errors.Is(err, failure) {
+			t.Errorf("expected: %q, actual: %q", failure, err)
+		}
+	})
+}
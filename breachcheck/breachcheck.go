@@ -0,0 +1,162 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+// Package breachcheck checks passwords against the Have I Been Pwned Pwned Passwords corpus.
+// It uses the range API, so only the first five characters of the SHA-1 hash of a password
+// leave the machine and the service never learns the password or its full hash.
+package breachcheck
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultEndpoint is the URL of the Pwned Passwords range API.  The hash prefix is appended to
+// it.
+const DefaultEndpoint = "https://api.pwnedpasswords.com/range/"
+
+// DefaultTimeout is the longest a check made by Breached may take, unless set in the Client.
+const DefaultTimeout = 10 * time.Second
+
+// ErrUnexpectedStatus is the error returned when the range API responds with a status other
+// than 200 OK
+var ErrUnexpectedStatus = errors.New("unexpected response status")
+
+// ErrMalformedResponse is the error returned when the range API responds with a line which
+// isn't a hash suffix and a count
+var ErrMalformedResponse = errors.New("malformed response")
+
+// Client checks passwords against the Pwned Passwords range API.  The zero value uses
+// DefaultEndpoint and an HTTP client with DefaultTimeout.  A Client is safe for concurrent use
+// and can be passed to passwordgen's WithBreachCheck.
+type Client struct {
+	// HTTPClient sends the requests.  If nil, a client with a timeout of DefaultTimeout is
+	// used.
+	HTTPClient *http.Client
+
+	// Timeout bounds every check made by Breached, which has no context of its own, so a
+	// stalled network can't block Generate forever.  If 0, DefaultTimeout is used.
+	Timeout time.Duration
+
+	// Endpoint is the URL to which the hash prefix is appended.  If empty, DefaultEndpoint is
+	// used.
+	Endpoint string
+
+	// UserAgent is sent with every request, as the API rejects requests without one.  If
+	// empty, "passwordgen-breachcheck" is used.
+	UserAgent string
+}
+
+// defaultHTTPClient is the client used when the Client doesn't set one.
+var defaultHTTPClient = &http.Client{Timeout: DefaultTimeout}
+
+// New returns a Client which uses DefaultEndpoint and a client with DefaultTimeout.
+func New() *Client {
+	return &Client{}
+}
+
+// Count returns the number of times password appears in the Pwned Passwords corpus, 0 if it
+// doesn't.  Padding is requested so the size of the response doesn't reveal the prefix.
+func (c *Client) Count(ctx context.Context, password string) (int, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint()+prefix, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", c.userAgent())
+	req.Header.Set("Add-Padding", "true")
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("%w: %s", ErrUnexpectedStatus, resp.Status)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		candidate, count, ok := strings.Cut(line, ":")
+		if !ok {
+			return 0, fmt.Errorf("%w: %q", ErrMalformedResponse, line)
+		}
+		if !strings.EqualFold(candidate, suffix) {
+			continue
+		}
+		n, err := strconv.Atoi(count)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q", ErrMalformedResponse, line)
+		}
+		return n, nil
+	}
+	return 0, scanner.Err()
+}
+
+// Breached returns whether password appears in the Pwned Passwords corpus.  Padding entries,
+// which have a count of 0, are not breaches.  The check is given up after the Timeout of the
+// Client.
+func (c *Client) Breached(password string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout())
+	defer cancel()
+	n, err := c.Count(ctx, password)
+	return n > 0, err
+}
+
+// endpoint returns the URL the hash prefix is appended to, DefaultEndpoint unless set in the
+// Client.
+func (c *Client) endpoint() string {
+	if c.Endpoint == "" {
+		return DefaultEndpoint
+	}
+	return c.Endpoint
+}
+
+// userAgent returns the User-Agent sent with every request, "passwordgen-breachcheck" unless
+// set in the Client.
+func (c *Client) userAgent() string {
+	if c.UserAgent == "" {
+		return "passwordgen-breachcheck"
+	}
+	return c.UserAgent
+}
+
+// httpClient returns the client which sends the requests, a client with DefaultTimeout unless
+// set in the Client.
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient == nil {
+		return defaultHTTPClient
+	}
+	return c.HTTPClient
+}
+
+// timeout returns how long a check made by Breached may take, DefaultTimeout unless set in the
+// Client.
+func (c *Client) timeout() time.Duration {
+	if c.Timeout <= 0 {
+		return DefaultTimeout
+	}
+	return c.Timeout
+}
@@ -0,0 +1,143 @@
+package breachcheck
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kenXengineering/passwordgen"
+)
+
+var _ passwordgen.BreachChecker = (*Client)(nil)
+
+// newServer returns a range API which knows the given passwords and their counts, plus padding.
+func newServer(t *testing.T, breached map[string]int) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") == "" || r.Header.Get("Add-Padding") != "true" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		prefix := strings.TrimPrefix(r.URL.Path, "/range/")
+		if len(prefix) != 5 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, "0000000000000000000000000000000000A:0\r\n")
+		for password, count := range breached {
+			sum := sha1.Sum([]byte(password))
+			hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+			if hash[:5] == prefix {
+				fmt.Fprintf(w, "%s:%d\r\n", hash[5:], count)
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient_Count(t *testing.T) {
+	t.Parallel()
+	server := newServer(t, map[string]int{"password": 42})
+	client := &Client{Endpoint: server.URL + "/range/"}
+
+	t.Run("breached", func(t *testing.T) {
+		t.Parallel()
+		n, err := client.Count(context.Background(), "password")
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if n != 42 {
+			t.Errorf("expected: %d, actual: %d", 42, n)
+		}
+		breached, err := client.Breached("password")
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if !breached {
+			t.Error("expected password to be breached")
+		}
+	})
+
+	t.Run("not_breached", func(t *testing.T) {
+		t.Parallel()
+		breached, err := client.Breached("x7#Lq9!vR2@m")
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if breached {
+			t.Error("expected password not to be breached")
+		}
+	})
+
+	t.Run("status", func(t *testing.T) {
+		t.Parallel()
+		client := &Client{Endpoint: server.URL + "/missing/"}
+		if _, err := client.Breached("password"); !errors.Is(err, ErrUnexpectedStatus) {
+			t.Errorf("expected: %q, actual: %q", ErrUnexpectedStatus, err)
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, "not a hash\r\n")
+		}))
+		defer server.Close()
+		client := &Client{Endpoint: server.URL + "/"}
+		if _, err := client.Breached("password"); !errors.Is(err, ErrMalformedResponse) {
+			t.Errorf("expected: %q, actual: %q", ErrMalformedResponse, err)
+		}
+	})
+}
+
+func TestClient_Breached(t *testing.T) {
+	t.Parallel()
+
+	t.Run("timeout", func(t *testing.T) {
+		t.Parallel()
+		stalled := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-stalled:
+			case <-r.Context().Done():
+			}
+		}))
+		t.Cleanup(func() {
+			close(stalled)
+			server.Close()
+		})
+		client := &Client{Endpoint: server.URL + "/range/", Timeout: 50 * time.Millisecond}
+		if _, err := client.Breached("password"); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected: %q, actual: %q", context.DeadlineExceeded, err)
+		}
+	})
+
+	t.Run("default_client_timeout", func(t *testing.T) {
+		t.Parallel()
+		if timeout := (&Client{}).httpClient().Timeout; timeout != DefaultTimeout {
+			t.Errorf("expected: %s, actual: %s", DefaultTimeout, timeout)
+		}
+	})
+}
+
+func TestGenerator_WithBreachCheck(t *testing.T) {
+	t.Parallel()
+	// Every 1 character digit password is breached, so the generator can never succeed.
+	breached := map[string]int{}
+	for d := '0'; d <= '9'; d++ {
+		breached[string(d)] = 1
+	}
+	server := newServer(t, breached)
+	gen := passwordgen.NewGenerator().WithDigits().WithBreachCheck(&Client{Endpoint: server.URL + "/range/"})
+	if _, err := gen.Generate(1); !errors.Is(err, passwordgen.ErrMaxAttemptsExceeded) {
+		t.Errorf("expected: %q, actual: %q", passwordgen.ErrMaxAttemptsExceeded, err)
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import (
+	"strings"
+	"sync"
+)
+
+// poolCache holds the pools built from the configuration of a generator, so they are built
+// once rather than on every call to Generate.  Every builder method which changes the
+// characters of a class, or which classes fill the password, replaces the cache of the
+// generator with an empty one.
+type poolCache struct {
+	once sync.Once
+
+	// elements are the elements of each class, indexed by CharClass.
+	elements [4][]string
+
+	// fill and pool are the elements the free positions are drawn from, and the same
+	// elements joined.
+	fill []string
+	pool string
+
+	// weighted is the fill pool weighted by WithClassWeights and WithEnglishLetterFrequency.
+	weighted *weightedPool
+
+	// fillNoSymbols and weightedNoSymbols are the fill pools without symbols, drawn once
+	// MaxSymbolFraction is reached.
+	fillNoSymbols     []string
+	weightedNoSymbols *weightedPool
+}
+
+// invalidate discards the pools built from the previous configuration.  The cache is replaced
+// rather than reset so clones sharing the previous cache are unaffected.
+func (g *Generator) invalidate() {
+	g.cache = &poolCache{}
+}
+
+// pools returns the pools built from the current configuration, building them if needed.
+func (g *Generator) pools() *poolCache {
+	cache := g.cache
+	if cache == nil {
+		// Generators which weren't created by NewGenerator don't have a cache to fill.
+		cache = &poolCache{}
+	}
+	cache.once.Do(func() {
+		cache.elements = g.removeConfusables([4][]string{
+			g.withoutExcluded(split(g.lowerLetters)),
+			g.withoutExcluded(split(g.upperLetters)),
+			g.withoutExcluded(split(g.digits)),
+			g.withoutExcluded(g.symbolElements()),
+		})
+		for class, with := range [4]bool{g.withLower, g.withUpper, g.withDigits, g.withSymbols} {
+			if !with || (g.hasClassWeights && g.classWeights[class] == 0) {
+				continue
+			}
+			cache.fill = append(cache.fill, cache.elements[class]...)
+			if CharClass(class) != ClassSymbol {
+				cache.fillNoSymbols = append(cache.fillNoSymbols, cache.elements[class]...)
+			}
+		}
+		cache.fill = append(cache.fill, g.charsetFill()...)
+		cache.fillNoSymbols = append(cache.fillNoSymbols, g.charsetFill()...)
+		cache.pool = strings.Join(cache.fill, "")
+		cache.weighted = g.weightedFillPool(cache.elements, g.charsetPools())
+		noSymbols := cache.elements
+		noSymbols[ClassSymbol] = nil
+		cache.weightedNoSymbols = g.weightedFillPool(noSymbols, g.charsetPools())
+	})
+	return cache
+}
@@ -0,0 +1,71 @@
+package passwordgen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerator_PoolCache(t *testing.T) {
+	t.Parallel()
+
+	gen := NewGenerator().WithLower()
+	if _, err := gen.Generate(16); err != nil {
+		t.Fatalf("expected no error, received %q", err)
+	}
+
+	// check generates passwords and reports any character outside of allowed.
+	check := func(allowed string) {
+		for i := 0; i < 50; i++ {
+			pass, err := gen.Generate(32)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			for _, r := range pass {
+				if !strings.ContainsRune(allowed, r) {
+					t.Fatalf("password %s contains %c which is not in %s", pass, r, allowed)
+				}
+			}
+		}
+	}
+
+	gen.NoAmbiguousLetters()
+	check(LowerLettersNoAmbig)
+
+	gen.WithCustomSymbols("#").WithSymbols()
+	check(LowerLettersNoAmbig + "#")
+
+	gen.ExactSymbols(0).WithDigits()
+	check(LowerLettersNoAmbig + Digits)
+
+	clone := gen.Clone().NoAmbiguousDigits()
+	check(LowerLettersNoAmbig + Digits)
+	pass, err := clone.Generate(32)
+	if err != nil {
+		t.Fatalf("expected no error, received %q", err)
+	}
+	if strings.ContainsAny(pass, "01") {
+		t.Errorf("password %s contains ambiguous digits", pass)
+	}
+}
+
+func BenchmarkGenerator_Generate(b *testing.B) {
+	gen := NewGenerator().NoAmbiguousCharacters().WithLower().WithUpper().WithDigits().WithSymbols()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := gen.Generate(16); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGenerator_GenerateUncached(b *testing.B) {
+	gen := NewGenerator().NoAmbiguousCharacters().WithLower().WithUpper().WithDigits().WithSymbols()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		// Discarding the cache rebuilds the pools like every call did before caching.
+		gen.invalidate()
+		if _, err := gen.Generate(16); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
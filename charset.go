@@ -0,0 +1,130 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownCharset is the error returned when RequireCharset or ExactCharset names a
+// charset which wasn't added with AddCharset
+var ErrUnknownCharset = errors.New("charset has not been added")
+
+// charset is a named class of characters added by AddCharset.
+type charset struct {
+	name     string
+	elements []string
+	added    bool
+	with     bool
+	require  int
+	exact    bool
+}
+
+// AddCharset adds a named class of characters, e.g. AddCharset("hex", "0123456789abcdef"), and
+// adds it to the password pool.  Calling AddCharset again with the same name replaces the
+// characters of the class.  Characters shared with other classes are more likely to be
+// drawn, as they are part of the pool more than once.
+func (g *Generator) AddCharset(name, chars string) *Generator {
+	set := g.charset(name)
+	set.elements = split(chars)
+	set.added = true
+	set.with = !set.exact
+	g.invalidate()
+	return g
+}
+
+// RequireCharset guarantees that at least N characters of the named charset will be in the
+// generated password.  Generate returns ErrUnknownCharset if the charset isn't added with
+// AddCharset.
+func (g *Generator) RequireCharset(name string, N int) *Generator {
+	set := g.charset(name)
+	set.require = N
+	set.exact = false
+	set.with = set.added
+	g.invalidate()
+	return g
+}
+
+// ExactCharset guarantees that exactly N characters of the named charset are drawn into the
+// generated password, the charset is removed from the pool filling the rest of it.
+// Characters it shares with other enabled classes may still appear.  Generate returns
+// ErrUnknownCharset if the charset isn't added with AddCharset.
+func (g *Generator) ExactCharset(name string, N int) *Generator {
+	set := g.charset(name)
+	set.require = N
+	set.exact = true
+	set.with = false
+	g.invalidate()
+	return g
+}
+
+// charset returns the named charset, adding an empty one if it doesn't exist yet.
+func (g *Generator) charset(name string) *charset {
+	for i := range g.charsets {
+		if g.charsets[i].name == name {
+			return &g.charsets[i]
+		}
+	}
+	g.charsets = append(g.charsets, charset{name: name})
+	return &g.charsets[len(g.charsets)-1]
+}
+
+// charsetPools returns the elements of each charset which is part of the password pool.
+func (g *Generator) charsetPools() [][]string {
+	var pools [][]string
+	for _, set := range g.charsets {
+		if elements := g.withoutExcluded(set.elements); set.with && len(elements) > 0 {
+			pools = append(pools, elements)
+		}
+	}
+	return pools
+}
+
+// charsetFill returns the elements of the charsets which are part of the password pool.
+func (g *Generator) charsetFill() []string {
+	var fill []string
+	for _, pool := range g.charsetPools() {
+		fill = append(fill, pool...)
+	}
+	return fill
+}
+
+// setRequirements returns the requirements of RequireFromSet along with the required
+// charsets.
+func (g *Generator) setRequirements() []setRequirement {
+	if len(g.charsets) == 0 && g.excluded == "" {
+		return g.requiredSets
+	}
+	var sets []setRequirement
+	for _, set := range g.requiredSets {
+		sets = append(sets, setRequirement{elements: g.withoutExcluded(set.elements), n: set.n})
+	}
+	for _, set := range g.charsets {
+		if set.require > 0 {
+			sets = append(sets, setRequirement{elements: g.withoutExcluded(set.elements), n: set.require})
+		}
+	}
+	return sets
+}
+
+// validateCharsets checks every required charset was added, and no charset in use is empty.
+func (g *Generator) validateCharsets() error {
+	for _, set := range g.charsets {
+		if !set.added {
+			return fmt.Errorf("%w: %q", ErrUnknownCharset, set.name)
+		}
+		if (set.with || set.require > 0) && len(g.withoutExcluded(set.elements)) == 0 {
+			return ErrEmptyCharacterSet
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package passwordgen
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGenerator_AddCharset(t *testing.T) {
+	t.Parallel()
+
+	t.Run("only_charset", func(t *testing.T) {
+		t.Parallel()
+		hex := "0123456789abcdef"
+		gen := NewGenerator().AddCharset("hex", hex)
+		for i := 0; i < 20; i++ {
+			pass, err := gen.Generate(16)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			for _, r := range pass {
+				if !strings.ContainsRune(hex, r) {
+					t.Errorf("character %c is not part of the charset %s", r, hex)
+				}
+			}
+		}
+	})
+
+	t.Run("replaces_characters", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().AddCharset("set", "a").AddCharset("set", "b")
+		pass, err := gen.Generate(8)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if pass != "bbbbbbbb" {
+			t.Errorf("expected: %s, actual: %s", "bbbbbbbb", pass)
+		}
+	})
+
+	t.Run("pool_size", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithDigits().AddCharset("hex", "0123456789abcdef")
+		if size := gen.PoolSize(); size != 16 {
+			t.Errorf("expected: %d, actual: %d", 16, size)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().AddCharset("empty", "")
+		if _, err := gen.Generate(8); !errors.Is(err, ErrEmptyCharacterSet) {
+			t.Errorf("expected: %q, actual: %q", ErrEmptyCharacterSet, err)
+		}
+	})
+
+	t.Run("weighted", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().AddCharset("greek", "αβγδ").BalancedClasses()
+		found := false
+		for i := 0; i < 20; i++ {
+			pass, err := gen.GenerateChecked(16)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			found = found || strings.ContainsAny(pass, "αβγδ")
+		}
+		if !found {
+			t.Error("expected the charset to appear in the generated passwords")
+		}
+	})
+}
+
+func TestGenerator_RequireCharset(t *testing.T) {
+	t.Parallel()
+
+	t.Run("at_least", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithUpper().AddCharset("greek", "αβγδ").RequireCharset("greek", 3)
+		for i := 0; i < 20; i++ {
+			pass, err := gen.GenerateChecked(8)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			count := 0
+			for _, r := range pass {
+				if strings.ContainsRune("αβγδ", r) {
+					count++
+				}
+			}
+			if count < 3 {
+				t.Errorf("Expected password %s to have at least 3 greek letters", pass)
+			}
+		}
+	})
+
+	t.Run("before_add", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().RequireCharset("punctuation", 2).AddCharset("punctuation", ".,;")
+		if min := gen.MinLength(); min != 2 {
+			t.Errorf("expected: %d, actual: %d", 2, min)
+		}
+		if _, err := gen.Generate(8); err != nil {
+			t.Errorf("expected no error, received %q", err)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().RequireCharset("hex", 2)
+		if _, err := gen.Generate(8); !errors.Is(err, ErrUnknownCharset) {
+			t.Errorf("expected: %q, actual: %q", ErrUnknownCharset, err)
+		}
+	})
+
+	t.Run("unique", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().AddCharset("set", "abc").RequireCharset("set", 3).RequireUniquePerClass()
+		if _, err := gen.Generate(4); !errors.Is(err, ErrInsufficientUniqueCharacters) {
+			t.Errorf("expected: %q, actual: %q", ErrInsufficientUniqueCharacters, err)
+		}
+	})
+}
+
+func TestGenerator_ExactCharset(t *testing.T) {
+	t.Parallel()
+
+	t.Run("exact", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().AddCharset("punctuation", ".,;").ExactCharset("punctuation", 2)
+		for i := 0; i < 20; i++ {
+			pass, err := gen.GenerateChecked(10)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if count := strings.Count(pass, ".") + strings.Count(pass, ",") + strings.Count(pass, ";"); count != 2 {
+				t.Errorf("Expected password %s to have exactly 2 punctuation characters", pass)
+			}
+		}
+	})
+
+	t.Run("only_exact", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().AddCharset("punctuation", ".,;").ExactCharset("punctuation", 2)
+		if _, err := gen.Generate(2); err != nil {
+			t.Errorf("expected no error, received %q", err)
+		}
+		if _, err := gen.Generate(1); !errors.Is(err, ErrExceedsTotalLength) {
+			t.Errorf("expected: %q, actual: %q", ErrExceedsTotalLength, err)
+		}
+		if _, err := gen.Generate(3); !errors.Is(err, ErrNoCharactersSpecified) {
+			t.Errorf("expected: %q, actual: %q", ErrNoCharactersSpecified, err)
+		}
+	})
+}
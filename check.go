@@ -0,0 +1,133 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrSelfCheckFailed is the error returned by GenerateChecked when a generated
+// password doesn't satisfy the generator's own configuration
+var ErrSelfCheckFailed = errors.New("generated password failed the self-check")
+
+// GenerateChecked will generate a password at the specified length as configured, then verify
+// it satisfies every configured constraint before returning it: the characters come from the
+// enabled pools, the Require and Exact counts hold, as do the class diversity, positional, and
+// uniqueness constraints.  A violation is a bug in the generator and returns
+// ErrSelfCheckFailed.  It is a defensive mode for high-assurance users, Generate already
+// guarantees the same.
+func (g *Generator) GenerateChecked(length int) (string, error) {
+	elements, plan, err := g.generatePlanned(length)
+	if err != nil {
+		return "", err
+	}
+	if err := g.selfCheck(plan, elements); err != nil {
+		return "", err
+	}
+	return strings.Join(elements, ""), nil
+}
+
+// selfCheck verifies the elements satisfy the generator's configuration and the plan they were
+// drawn by.  The required counts are taken from the plan, they depend on the time with
+// WithTimeSeededClassRequirement and planning again could require another class.  The pools
+// are computed again rather than taken from the cache, so a stale cache is caught as well.
+func (g *Generator) selfCheck(plan *GenerationPlan, elements []string) error {
+	fail := func(format string, args ...interface{}) error {
+		return fmt.Errorf("%w: %s", ErrSelfCheckFailed, fmt.Sprintf(format, args...))
+	}
+
+	if len(elements) != plan.Length {
+		return fail("%d characters generated, %d requested", len(elements), plan.Length)
+	}
+
+	pools := g.removeConfusables([4][]string{
+		g.withoutExcluded(split(g.lowerLetters)),
+		g.withoutExcluded(split(g.upperLetters)),
+		g.withoutExcluded(split(g.digits)),
+		g.withoutExcluded(g.symbolElements()),
+	})
+	required := [4]int{plan.Lower, plan.Upper, plan.Digits, plan.Symbols}
+	exact := [4]bool{g.exactLower, g.exactUpper, g.exactDigits, g.exactSymbols}
+	enabled := [4]bool{g.withLower, g.withUpper, g.withDigits, g.withSymbols}
+
+	var counts [4]int
+	seen := map[string]bool{}
+	for _, elm := range elements {
+		allowed := false
+		for class, pool := range pools {
+			if (enabled[class] || required[class] > 0) && slices.Contains(pool, elm) {
+				allowed = true
+				counts[class]++
+			}
+		}
+		for _, set := range g.setRequirements() {
+			allowed = allowed || slices.Contains(set.elements, elm)
+		}
+		allowed = allowed || slices.Contains(g.charsetFill(), elm)
+		if !allowed {
+			return fail("%q isn't in the enabled pools", elm)
+		}
+		if g.uniquePerClass && seen[elm] {
+			return fail("%q is repeated", elm)
+		}
+		seen[elm] = true
+	}
+
+	present := 0
+	for class, count := range counts {
+		if count > 0 {
+			present++
+		}
+		if count < required[class] || (exact[class] && count != required[class]) {
+			return fail("%d %s characters, %d required", count, CharClass(class), required[class])
+		}
+	}
+	if present < g.classDiversity {
+		return fail("%d classes present, %d required", present, g.classDiversity)
+	}
+
+	for _, set := range g.setRequirements() {
+		count := 0
+		for _, elm := range elements {
+			if slices.Contains(set.elements, elm) {
+				count++
+			}
+		}
+		if count < set.n {
+			return fail("%d characters from a required set, %d required", count, set.n)
+		}
+	}
+
+	if g.hasSymbolFraction && counts[ClassSymbol] > max(plan.symbolCap, required[ClassSymbol]) {
+		return fail("%d symbols exceed the maximum fraction", counts[ClassSymbol])
+	}
+	for class, limited := range g.hasClassMax {
+		if limited && counts[class] > g.classMax[class] {
+			return fail("%d %s characters, at most %d allowed", counts[class], CharClass(class), g.classMax[class])
+		}
+	}
+	if !g.validPositions(elements) {
+		return fail("a positional constraint doesn't hold")
+	}
+	if g.forbidKeyboardSequences && keyboardWalk(elements) >= minKeyboardWalk {
+		return fail("a keyboard sequence is present")
+	}
+	if g.maxSequence > 0 && (sequenceRun(elements) > g.maxSequence || keyboardWalk(elements) > g.maxSequence) {
+		return fail("a sequence of more than %d characters is present", g.maxSequence)
+	}
+	if g.maxRepeat > 0 && longestRun(elements) > g.maxRepeat {
+		return fail("a character is repeated more than %d times in a row", g.maxRepeat)
+	}
+	return nil
+}
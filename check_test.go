@@ -0,0 +1,79 @@
+package passwordgen
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGenerator_GenerateChecked(t *testing.T) {
+	t.Parallel()
+
+	t.Run("passes", func(t *testing.T) {
+		t.Parallel()
+		for _, gen := range []*Generator{
+			NewGenerator().WithLower().WithUpper().RequireDigits(2).ExactSymbols(3),
+			NewGenerator().WithLower().WithUpper().WithDigits().WithSymbols().RequireClassDiversity(4),
+			NewGenerator().NoAmbiguousCharacters().WithLower().WithDigits().RequireUniquePerClass(),
+			NewGenerator().WithLower().RequireFromSet("@#$", 2).ForbidLeadingDigit().ForbidBoundarySymbols(),
+			NewGenerator().WithLower().WithSymbols().MaxSymbolFraction(0.25).ForbidKeyboardSequences(),
+		} {
+			for i := 0; i < 20; i++ {
+				if _, err := gen.GenerateChecked(16); err != nil {
+					t.Fatalf("expected no error, received %q", err)
+				}
+			}
+		}
+	})
+
+	t.Run("broken_state", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower()
+		// Corrupt the cached fill pool, the self-check computes the pools on its own.
+		gen.pools().fill = []string{"#"}
+		if _, err := gen.GenerateChecked(8); !errors.Is(err, ErrSelfCheckFailed) {
+			t.Errorf("expected: %q, actual: %q", ErrSelfCheckFailed, err)
+		}
+	})
+
+	t.Run("checks_generation_plan", func(t *testing.T) {
+		t.Parallel()
+		start := time.Date(2019, time.June, 1, 0, 0, 0, 0, time.UTC)
+		checked := false
+		gen := NewGenerator().WithLower().WithTimeSeededClassRequirement([]CharClass{ClassDigit, ClassSymbol}, time.Hour)
+		gen.now = func() time.Time {
+			if checked {
+				return start.Add(time.Hour)
+			}
+			return start
+		}
+		// The period changes once the password is generated, so planning again would require
+		// a symbol instead of the digit the password was generated with.
+		gen.WithBreachCheck(&fakeBreachChecker{onCheck: func(string) { checked = true }})
+		if _, err := gen.GenerateChecked(8); err != nil {
+			t.Errorf("expected no error, received %q", err)
+		}
+	})
+
+	t.Run("exact_class_in_required_set", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().ExactLower(3).RequireFromSet("abc", 1).WithDigits()
+		if _, err := gen.Generate(8); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("expected: %q, actual: %q", ErrInvalidConfig, err)
+		}
+		if _, err := gen.GenerateChecked(8); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("expected: %q, actual: %q", ErrInvalidConfig, err)
+		}
+		// A set outside the exact class doesn't change its count.
+		if _, err := NewGenerator().ExactLower(3).RequireFromSet("@#$", 1).WithDigits().GenerateChecked(8); err != nil {
+			t.Errorf("expected no error, received %q", err)
+		}
+	})
+
+	t.Run("generate_error", func(t *testing.T) {
+		t.Parallel()
+		if _, err := NewGenerator().GenerateChecked(8); !errors.Is(err, ErrNoCharactersSpecified) {
+			t.Errorf("expected: %q, actual: %q", ErrNoCharactersSpecified, err)
+		}
+	})
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+// CharClass identifies one of the character classes of a generator.
+type CharClass int
+
+const (
+	// ClassLower is the class of lower case letters.
+	ClassLower CharClass = iota
+
+	// ClassUpper is the class of upper case letters.
+	ClassUpper
+
+	// ClassDigit is the class of digits.
+	ClassDigit
+
+	// ClassSymbol is the class of symbols.
+	ClassSymbol
+)
+
+// String returns the name of the class.
+func (c CharClass) String() string {
+	switch c {
+	case ClassLower:
+		return "lower"
+	case ClassUpper:
+		return "upper"
+	case ClassDigit:
+		return "digit"
+	case ClassSymbol:
+		return "symbol"
+	}
+	return "unknown"
+}
+
+// valid returns whether c is one of the known classes.
+func (c CharClass) valid() bool {
+	return c >= ClassLower && c <= ClassSymbol
+}
+
+// classState is the configuration of a single class of a generator.
+type classState struct {
+	class    CharClass
+	with     bool
+	require  int
+	elements []string
+}
+
+// classes returns the configuration of every class of the generator, the required counts
+// include the class required by WithTimeSeededClassRequirement.
+func (g *Generator) classes() []classState {
+	requireLower, requireUpper, requireDigits, requireSymbols := g.requiredCounts()
+	elements := g.pools().elements
+	return []classState{
+		{ClassLower, g.withLower, requireLower, elements[ClassLower]},
+		{ClassUpper, g.withUpper, requireUpper, elements[ClassUpper]},
+		{ClassDigit, g.withDigits, requireDigits, elements[ClassDigit]},
+		{ClassSymbol, g.withSymbols, requireSymbols, elements[ClassSymbol]},
+	}
+}
@@ -0,0 +1,265 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+// Command passwordgen generates passwords, passphrases, PINs, and tokens from the command line.
+//
+// Usage:
+//
+//	passwordgen [command] [flags]
+//
+// The commands are password, the default, passphrase, pin, and token, and serve, which serves
+// the HTTP API of the server package.  Run passwordgen <command> -h for the flags of a command.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kenXengineering/passwordgen"
+	"github.com/kenXengineering/passwordgen/server"
+)
+
+// command is a subcommand, which generates a single secret per call of the returned function.
+type command struct {
+	usage string
+	setup func(fs *flag.FlagSet) func() (string, error)
+}
+
+// commands are the subcommands by name.
+var commands = map[string]command{
+	"password":   {"generate passwords", passwordCommand},
+	"passphrase": {"generate passphrases from the default wordlist", passphraseCommand},
+	"pin":        {"generate numeric PINs", pinCommand},
+	"token":      {"generate random tokens", tokenCommand},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the command line args and returns the exit code: 0 on success, 1 if generating
+// failed, and 2 for invalid usage.
+func run(args []string, stdout, stderr io.Writer) int {
+	name := "password"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+	if name == "serve" {
+		return serve(args, stderr)
+	}
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(stderr, "passwordgen: unknown command %q\n", name)
+		printUsage(stderr)
+		return 2
+	}
+
+	fs := flag.NewFlagSet("passwordgen "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	count := fs.Int("count", 1, "number of secrets to generate")
+	format := fs.String("format", "text", "output format, text or json")
+	generate := cmd.setup(fs)
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(stderr, "passwordgen: unexpected arguments %q\n", fs.Args())
+		return 2
+	}
+	if *count < 1 {
+		fmt.Fprintln(stderr, "passwordgen: -count must be positive")
+		return 2
+	}
+	if *format != "text" && *format != "json" {
+		fmt.Fprintf(stderr, "passwordgen: unknown format %q\n", *format)
+		return 2
+	}
+
+	secrets := make([]string, 0, *count)
+	for i := 0; i < *count; i++ {
+		secret, err := generate()
+		if err != nil {
+			fmt.Fprintf(stderr, "passwordgen: %s\n", err)
+			return 1
+		}
+		secrets = append(secrets, secret)
+	}
+
+	if *format == "json" {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(secrets); err != nil {
+			fmt.Fprintf(stderr, "passwordgen: %s\n", err)
+			return 1
+		}
+		return 0
+	}
+	for _, secret := range secrets {
+		fmt.Fprintln(stdout, secret)
+	}
+	return 0
+}
+
+// printUsage prints the commands.
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "usage: passwordgen [command] [flags]")
+	fmt.Fprintln(w, "commands:")
+	for _, name := range []string{"password", "passphrase", "pin", "token"} {
+		fmt.Fprintf(w, "  %-11s %s\n", name, commands[name].usage)
+	}
+	fmt.Fprintf(w, "  %-11s %s\n", "serve", "serve the HTTP API")
+}
+
+// serve runs the serve command, which serves the HTTP API until the listener fails.
+func serve(args []string, stderr io.Writer) int {
+	fs := flag.NewFlagSet("passwordgen serve", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	addr := fs.String("addr", "localhost:8080", "address to listen on")
+	maxCount := fs.Int("max-count", server.DefaultMaxCount, "most secrets generated by a single request")
+	maxLength := fs.Int("max-length", server.DefaultMaxLength, "most characters of a password")
+	maxWords := fs.Int("max-words", server.DefaultMaxWords, "most words of a passphrase")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(stderr, "passwordgen: unexpected arguments %q\n", fs.Args())
+		return 2
+	}
+
+	handler := server.New()
+	handler.MaxCount = *maxCount
+	handler.MaxLength = *maxLength
+	handler.MaxWords = *maxWords
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      30 * time.Second,
+	}
+	fmt.Fprintf(stderr, "passwordgen: serving on %s\n", *addr)
+	if err := srv.ListenAndServe(); err != nil {
+		fmt.Fprintf(stderr, "passwordgen: %s\n", err)
+		return 1
+	}
+	return 0
+}
+
+// passwordCommand registers the flags of the password command.
+func passwordCommand(fs *flag.FlagSet) func() (string, error) {
+	length := fs.Int("length", passwordgen.DefaultLength, "number of characters")
+	lower := fs.Bool("lower", true, "include lower case letters")
+	upper := fs.Bool("upper", true, "include upper case letters")
+	digits := fs.Bool("digits", true, "include digits")
+	symbols := fs.Bool("symbols", true, "include symbols")
+	requireLower := fs.Int("require-lower", 0, "least number of lower case letters")
+	requireUpper := fs.Int("require-upper", 0, "least number of upper case letters")
+	requireDigits := fs.Int("require-digits", 0, "least number of digits")
+	requireSymbols := fs.Int("require-symbols", 0, "least number of symbols")
+	noAmbiguous := fs.Bool("no-ambiguous", false, "leave out characters which are easily confused, such as l, 1, O, and 0")
+	exclude := fs.String("exclude", "", "characters to leave out")
+
+	var gen *passwordgen.Generator
+	return func() (string, error) {
+		if gen == nil {
+			gen = passwordgen.NewGenerator()
+			if *lower {
+				gen.WithLower()
+			}
+			if *upper {
+				gen.WithUpper()
+			}
+			if *digits {
+				gen.WithDigits()
+			}
+			if *symbols {
+				gen.WithSymbols()
+			}
+			if *noAmbiguous {
+				gen.NoAmbiguousCharacters()
+			}
+			// Require<type> enables its class, only call it for the classes with a requirement.
+			if *requireLower > 0 {
+				gen.RequireLower(*requireLower)
+			}
+			if *requireUpper > 0 {
+				gen.RequireUpper(*requireUpper)
+			}
+			if *requireDigits > 0 {
+				gen.RequireDigits(*requireDigits)
+			}
+			if *requireSymbols > 0 {
+				gen.RequireSymbols(*requireSymbols)
+			}
+			gen.ExcludeCharacters(*exclude)
+		}
+		return gen.Generate(*length)
+	}
+}
+
+// passphraseCommand registers the flags of the passphrase command.
+func passphraseCommand(fs *flag.FlagSet) func() (string, error) {
+	words := fs.Int("words", 6, "number of words")
+	separator := fs.String("separator", passwordgen.DefaultPassphraseSeparator, "string between the words")
+	capitalize := fs.Bool("capitalize", false, "capitalize the first letter of every word")
+	return func() (string, error) {
+		gen := passwordgen.NewPassphraseGenerator().WithSeparator(*separator)
+		if *capitalize {
+			gen.Capitalize(passwordgen.CapitalizeTitle)
+		}
+		return gen.Generate(*words)
+	}
+}
+
+// pinCommand registers the flags of the pin command.
+func pinCommand(fs *flag.FlagSet) func() (string, error) {
+	length := fs.Int("length", 6, "number of digits")
+	strong := fs.Bool("strong", false, "reject guessable PINs such as 1234, 0000, or 1990")
+	return func() (string, error) {
+		if *strong {
+			return passwordgen.GenerateStrongPIN(*length)
+		}
+		return passwordgen.GeneratePIN(*length)
+	}
+}
+
+// encodings maps the names accepted by the token command to encodings.
+var encodings = map[string]passwordgen.Encoding{
+	"hex":       passwordgen.EncodingHex,
+	"base64url": passwordgen.EncodingBase64URL,
+	"base32":    passwordgen.EncodingBase32,
+	"crockford": passwordgen.EncodingCrockford,
+}
+
+// tokenCommand registers the flags of the token command.
+func tokenCommand(fs *flag.FlagSet) func() (string, error) {
+	bytes := fs.Int("bytes", 32, "number of random bytes")
+	encoding := fs.String("encoding", "hex", "encoding of the bytes, hex, base64url, base32, or crockford")
+	return func() (string, error) {
+		enc, ok := encodings[*encoding]
+		if !ok {
+			return "", fmt.Errorf("unknown encoding %q", *encoding)
+		}
+		return passwordgen.GenerateToken(*bytes, enc)
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default_password", func(t *testing.T) {
+		t.Parallel()
+
+		var stdout, stderr bytes.Buffer
+		if code := run(nil, &stdout, &stderr); code != 0 {
+			t.Fatalf("expected: %d, actual: %d, stderr: %q", 0, code, stderr.String())
+		}
+		if actual := strings.TrimSuffix(stdout.String(), "\n"); len(actual) != 16 {
+			t.Errorf("expected: %d, actual: %d", 16, len(actual))
+		}
+	})
+
+	t.Run("password_flags", func(t *testing.T) {
+		t.Parallel()
+
+		var stdout, stderr bytes.Buffer
+		args := []string{"password", "-length", "20", "-symbols=false", "-upper=false", "-require-digits", "5", "-no-ambiguous", "-count", "3"}
+		if code := run(args, &stdout, &stderr); code != 0 {
+			t.Fatalf("expected: %d, actual: %d, stderr: %q", 0, code, stderr.String())
+		}
+		lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+		if len(lines) != 3 {
+			t.Fatalf("expected: %d, actual: %d", 3, len(lines))
+		}
+		for _, line := range lines {
+			if len(line) != 20 {
+				t.Errorf("expected: %d, actual: %d", 20, len(line))
+			}
+			digits := 0
+			for _, r := range line {
+				if unicode.IsDigit(r) {
+					digits++
+				} else if !unicode.IsLower(r) {
+					t.Errorf("expected only lower case letters and digits, received %q", line)
+				}
+			}
+			if digits < 5 {
+				t.Errorf("expected at least 5 digits, received %q", line)
+			}
+			if strings.ContainsAny(line, "l1O0") {
+				t.Errorf("expected no ambiguous characters, received %q", line)
+			}
+		}
+	})
+
+	t.Run("passphrase", func(t *testing.T) {
+		t.Parallel()
+
+		var stdout, stderr bytes.Buffer
+		if code := run([]string{"passphrase", "-words", "4", "-separator", "_"}, &stdout, &stderr); code != 0 {
+			t.Fatalf("expected: %d, actual: %d, stderr: %q", 0, code, stderr.String())
+		}
+		words := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "_")
+		if len(words) != 4 {
+			t.Errorf("expected: %d, actual: %d", 4, len(words))
+		}
+	})
+
+	t.Run("pin", func(t *testing.T) {
+		t.Parallel()
+
+		var stdout, stderr bytes.Buffer
+		if code := run([]string{"pin", "-length", "8", "-strong"}, &stdout, &stderr); code != 0 {
+			t.Fatalf("expected: %d, actual: %d, stderr: %q", 0, code, stderr.String())
+		}
+		pin := strings.TrimSuffix(stdout.String(), "\n")
+		if len(pin) != 8 || strings.IndexFunc(pin, func(r rune) bool { return !unicode.IsDigit(r) }) >= 0 {
+			t.Errorf("expected 8 digits, received %q", pin)
+		}
+	})
+
+	t.Run("token_json", func(t *testing.T) {
+		t.Parallel()
+
+		var stdout, stderr bytes.Buffer
+		if code := run([]string{"token", "-bytes", "16", "-count", "2", "-format", "json"}, &stdout, &stderr); code != 0 {
+			t.Fatalf("expected: %d, actual: %d, stderr: %q", 0, code, stderr.String())
+		}
+		var tokens []string
+		if err := json.Unmarshal(stdout.Bytes(), &tokens); err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if len(tokens) != 2 {
+			t.Fatalf("expected: %d, actual: %d", 2, len(tokens))
+		}
+		for _, token := range tokens {
+			if len(token) != 32 {
+				t.Errorf("expected: %d, actual: %d", 32, len(token))
+			}
+		}
+	})
+
+	t.Run("usage_errors", func(t *testing.T) {
+		t.Parallel()
+
+		for _, args := range [][]string{
+			{"unknown"},
+			{"password", "-bogus"},
+			{"password", "extra"},
+			{"password", "-count", "0"},
+			{"password", "-format", "xml"},
+			{"serve", "-bogus"},
+			{"serve", "extra"},
+		} {
+			var stdout, stderr bytes.Buffer
+			if code := run(args, &stdout, &stderr); code != 2 {
+				t.Errorf("expected: %d, actual: %d for %q", 2, code, args)
+			}
+		}
+	})
+
+	t.Run("generation_errors", func(t *testing.T) {
+		t.Parallel()
+
+		for _, args := range [][]string{
+			{"password", "-length", "4", "-require-digits", "5"},
+			{"token", "-encoding", "base58"},
+			{"serve", "-addr", ":-1"},
+			{"password", "-lower=false", "-upper=false", "-symbols=false", "-exclude", "0123456789"},
+		} {
+			var stdout, stderr bytes.Buffer
+			if code := run(args, &stdout, &stderr); code != 1 {
+				t.Errorf("expected: %d, actual: %d for %q", 1, code, args)
+			}
+			if stderr.Len() == 0 {
+				t.Errorf("expected an error message for %q", args)
+			}
+		}
+	})
+}
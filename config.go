@@ -0,0 +1,415 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidConfig is the error returned when a generator configuration
+	// contains values that can never produce a password
+	ErrInvalidConfig = errors.New("invalid generator configuration")
+
+	// ErrUnserializableConfig is the error returned when a generator is encoded
+	// with a BreachChecker or a source of randomness, which Config can't hold
+	ErrUnserializableConfig = errors.New("generator configuration can't be serialized")
+)
+
+// Config is the serializable form of a Generator, suitable for storing a
+// password policy and reconstructing the generator later.  Fields carry both
+// JSON and YAML tags so a configuration can be kept in either kind of file.
+//
+// Every option of the generator is part of the configuration, except the
+// BreachChecker set with WithBreachCheck and the source of randomness set with
+// WithRand, which aren't settings but dependencies.  ToConfig leaves them out,
+// and MarshalJSON returns ErrUnserializableConfig rather than dropping them.
+type Config struct {
+	LowerLetters string `json:"lowerLetters" yaml:"lowerLetters"`
+	UpperLetters string `json:"upperLetters" yaml:"upperLetters"`
+	Digits       string `json:"digits" yaml:"digits"`
+	Symbols      string `json:"symbols" yaml:"symbols"`
+
+	WithLower   bool `json:"withLower" yaml:"withLower"`
+	WithUpper   bool `json:"withUpper" yaml:"withUpper"`
+	WithDigits  bool `json:"withDigits" yaml:"withDigits"`
+	WithSymbols bool `json:"withSymbols" yaml:"withSymbols"`
+
+	RequireLower   int `json:"requireLower" yaml:"requireLower"`
+	RequireUpper   int `json:"requireUpper" yaml:"requireUpper"`
+	RequireDigits  int `json:"requireDigits" yaml:"requireDigits"`
+	RequireSymbols int `json:"requireSymbols" yaml:"requireSymbols"`
+
+	ExactLower   bool `json:"exactLower,omitempty" yaml:"exactLower,omitempty"`
+	ExactUpper   bool `json:"exactUpper,omitempty" yaml:"exactUpper,omitempty"`
+	ExactDigits  bool `json:"exactDigits,omitempty" yaml:"exactDigits,omitempty"`
+	ExactSymbols bool `json:"exactSymbols,omitempty" yaml:"exactSymbols,omitempty"`
+
+	ClassDiversity int `json:"classDiversity,omitempty" yaml:"classDiversity,omitempty"`
+
+	RequiredSets []SetConfig     `json:"requiredSets,omitempty" yaml:"requiredSets,omitempty"`
+	Charsets     []CharsetConfig `json:"charsets,omitempty" yaml:"charsets,omitempty"`
+	Emojis       []string        `json:"emojis,omitempty" yaml:"emojis,omitempty"`
+
+	EnglishLetterFrequency bool     `json:"englishLetterFrequency,omitempty" yaml:"englishLetterFrequency,omitempty"`
+	ClassWeights           *[4]int  `json:"classWeights,omitempty" yaml:"classWeights,omitempty"`
+	BalancedClasses        bool     `json:"balancedClasses,omitempty" yaml:"balancedClasses,omitempty"`
+	CaseRatio              *float64 `json:"caseRatio,omitempty" yaml:"caseRatio,omitempty"`
+
+	MaxLower          *int     `json:"maxLower,omitempty" yaml:"maxLower,omitempty"`
+	MaxUpper          *int     `json:"maxUpper,omitempty" yaml:"maxUpper,omitempty"`
+	MaxDigits         *int     `json:"maxDigits,omitempty" yaml:"maxDigits,omitempty"`
+	MaxSymbols        *int     `json:"maxSymbols,omitempty" yaml:"maxSymbols,omitempty"`
+	MaxSymbolFraction *float64 `json:"maxSymbolFraction,omitempty" yaml:"maxSymbolFraction,omitempty"`
+
+	ExcludedCharacters string `json:"excludedCharacters,omitempty" yaml:"excludedCharacters,omitempty"`
+
+	Length    int `json:"length,omitempty" yaml:"length,omitempty"`
+	MaxLength int `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	MaxRepeat int `json:"maxRepeat,omitempty" yaml:"maxRepeat,omitempty"`
+
+	AutoLength  bool    `json:"autoLength,omitempty" yaml:"autoLength,omitempty"`
+	MinEntropy  float64 `json:"minEntropy,omitempty" yaml:"minEntropy,omitempty"`
+	MaxAttempts int     `json:"maxAttempts,omitempty" yaml:"maxAttempts,omitempty"`
+
+	ForbidKeyboardSequences bool `json:"forbidKeyboardSequences,omitempty" yaml:"forbidKeyboardSequences,omitempty"`
+	MaxSequence             int  `json:"maxSequence,omitempty" yaml:"maxSequence,omitempty"`
+	ForbidLeadingDigit      bool `json:"forbidLeadingDigit,omitempty" yaml:"forbidLeadingDigit,omitempty"`
+	ForbidBoundarySymbols   bool `json:"forbidBoundarySymbols,omitempty" yaml:"forbidBoundarySymbols,omitempty"`
+	StartWithLetter         bool `json:"startWithLetter,omitempty" yaml:"startWithLetter,omitempty"`
+
+	Pins                    []PinConfig `json:"pins,omitempty" yaml:"pins,omitempty"`
+	UniquePerClass          bool        `json:"uniquePerClass,omitempty" yaml:"uniquePerClass,omitempty"`
+	NoCrossClassConfusables bool        `json:"noCrossClassConfusables,omitempty" yaml:"noCrossClassConfusables,omitempty"`
+
+	RotatingClasses []CharClass   `json:"rotatingClasses,omitempty" yaml:"rotatingClasses,omitempty"`
+	RotationPeriod  time.Duration `json:"rotationPeriod,omitempty" yaml:"rotationPeriod,omitempty"`
+}
+
+// SetConfig is the serializable form of a requirement added by RequireFromSet.
+type SetConfig struct {
+	Characters string `json:"characters" yaml:"characters"`
+	Count      int    `json:"count" yaml:"count"`
+}
+
+// CharsetConfig is the serializable form of a charset set with AddCharset, RequireCharset, or
+// ExactCharset.  Added is false for a charset which is required but hasn't been added.
+type CharsetConfig struct {
+	Name       string `json:"name" yaml:"name"`
+	Characters string `json:"characters,omitempty" yaml:"characters,omitempty"`
+	Added      bool   `json:"added,omitempty" yaml:"added,omitempty"`
+	With       bool   `json:"with,omitempty" yaml:"with,omitempty"`
+	Require    int    `json:"require,omitempty" yaml:"require,omitempty"`
+	Exact      bool   `json:"exact,omitempty" yaml:"exact,omitempty"`
+}
+
+// PinConfig is the serializable form of a position pinned by PinPosition.
+type PinConfig struct {
+	Index int       `json:"index" yaml:"index"`
+	Class CharClass `json:"class" yaml:"class"`
+}
+
+// ToConfig returns the configuration of the generator, see Config for what it holds.
+func (g *Generator) ToConfig() Config {
+	c := Config{
+		LowerLetters: g.lowerLetters,
+		UpperLetters: g.upperLetters,
+		Digits:       g.digits,
+		Symbols:      g.symbols,
+
+		WithLower:   g.withLower,
+		WithUpper:   g.withUpper,
+		WithDigits:  g.withDigits,
+		WithSymbols: g.withSymbols,
+
+		RequireLower:   g.requireLower,
+		RequireUpper:   g.requireUpper,
+		RequireDigits:  g.requireDigits,
+		RequireSymbols: g.requireSymbols,
+
+		ExactLower:   g.exactLower,
+		ExactUpper:   g.exactUpper,
+		ExactDigits:  g.exactDigits,
+		ExactSymbols: g.exactSymbols,
+
+		ClassDiversity: g.classDiversity,
+
+		Emojis: append([]string(nil), g.emojis...),
+
+		EnglishLetterFrequency: g.englishFrequency,
+		BalancedClasses:        g.balancedClasses,
+
+		ExcludedCharacters: g.excluded,
+
+		Length:    g.length,
+		MaxLength: g.maxLength,
+		MaxRepeat: g.maxRepeat,
+
+		AutoLength:  g.autoLength,
+		MinEntropy:  g.minEntropy,
+		MaxAttempts: g.maxAttempts,
+
+		ForbidKeyboardSequences: g.forbidKeyboardSequences,
+		MaxSequence:             g.maxSequence,
+		ForbidLeadingDigit:      g.forbidLeadingDigit,
+		ForbidBoundarySymbols:   g.forbidBoundarySymbols,
+		StartWithLetter:         g.startWithLetter,
+
+		UniquePerClass:          g.uniquePerClass,
+		NoCrossClassConfusables: g.noCrossClassConfusables,
+
+		RotatingClasses: append([]CharClass(nil), g.rotatingClasses...),
+		RotationPeriod:  g.rotationPeriod,
+	}
+
+	for _, set := range g.requiredSets {
+		c.RequiredSets = append(c.RequiredSets, SetConfig{Characters: strings.Join(set.elements, ""), Count: set.n})
+	}
+	for _, set := range g.charsets {
+		c.Charsets = append(c.Charsets, CharsetConfig{
+			Name:       set.name,
+			Characters: strings.Join(set.elements, ""),
+			Added:      set.added,
+			With:       set.with,
+			Require:    set.require,
+			Exact:      set.exact,
+		})
+	}
+	for _, p := range g.pins {
+		c.Pins = append(c.Pins, PinConfig{Index: p.index, Class: p.class})
+	}
+
+	if g.hasClassWeights {
+		weights := g.classWeights
+		c.ClassWeights = &weights
+	}
+	if g.hasCaseRatio {
+		ratio := g.caseRatio
+		c.CaseRatio = &ratio
+	}
+	if g.hasSymbolFraction {
+		fraction := g.maxSymbolFraction
+		c.MaxSymbolFraction = &fraction
+	}
+	for class, limit := range []**int{&c.MaxLower, &c.MaxUpper, &c.MaxDigits, &c.MaxSymbols} {
+		if g.hasClassMax[class] {
+			n := g.classMax[class]
+			*limit = &n
+		}
+	}
+	return c
+}
+
+// FromConfig returns a new generator built from the given configuration.
+func FromConfig(c Config) (*Generator, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	g := &Generator{
+		lowerLetters: c.LowerLetters,
+		upperLetters: c.UpperLetters,
+		digits:       c.Digits,
+		symbols:      c.Symbols,
+
+		withLower:   c.WithLower,
+		withUpper:   c.WithUpper,
+		withDigits:  c.WithDigits,
+		withSymbols: c.WithSymbols,
+
+		requireLower:   c.RequireLower,
+		requireUpper:   c.RequireUpper,
+		requireDigits:  c.RequireDigits,
+		requireSymbols: c.RequireSymbols,
+
+		// A required count for a class which isn't in the pool is exact, so configurations
+		// written before the Exact fields keep their meaning.
+		exactLower:   c.ExactLower || (!c.WithLower && c.RequireLower > 0),
+		exactUpper:   c.ExactUpper || (!c.WithUpper && c.RequireUpper > 0),
+		exactDigits:  c.ExactDigits || (!c.WithDigits && c.RequireDigits > 0),
+		exactSymbols: c.ExactSymbols || (!c.WithSymbols && c.RequireSymbols > 0),
+
+		classDiversity: c.ClassDiversity,
+
+		emojis: append([]string(nil), c.Emojis...),
+
+		englishFrequency: c.EnglishLetterFrequency,
+		balancedClasses:  c.BalancedClasses,
+
+		excluded: c.ExcludedCharacters,
+
+		length:    c.Length,
+		maxLength: c.MaxLength,
+		maxRepeat: c.MaxRepeat,
+
+		autoLength:  c.AutoLength,
+		minEntropy:  c.MinEntropy,
+		maxAttempts: c.MaxAttempts,
+
+		forbidKeyboardSequences: c.ForbidKeyboardSequences,
+		maxSequence:             c.MaxSequence,
+		forbidLeadingDigit:      c.ForbidLeadingDigit,
+		forbidBoundarySymbols:   c.ForbidBoundarySymbols,
+		startWithLetter:         c.StartWithLetter,
+
+		uniquePerClass:          c.UniquePerClass,
+		noCrossClassConfusables: c.NoCrossClassConfusables,
+
+		rotatingClasses: append([]CharClass(nil), c.RotatingClasses...),
+		rotationPeriod:  c.RotationPeriod,
+
+		cache: &poolCache{},
+	}
+
+	for _, set := range c.RequiredSets {
+		g.requiredSets = append(g.requiredSets, setRequirement{elements: split(set.Characters), n: set.Count})
+	}
+	for _, set := range c.Charsets {
+		g.charsets = append(g.charsets, charset{
+			name:     set.Name,
+			elements: split(set.Characters),
+			added:    set.Added,
+			with:     set.With,
+			require:  set.Require,
+			exact:    set.Exact,
+		})
+	}
+	for _, p := range c.Pins {
+		g.pins = append(g.pins, pin{index: p.Index, class: p.Class})
+	}
+
+	if c.ClassWeights != nil {
+		g.classWeights, g.hasClassWeights = *c.ClassWeights, true
+	}
+	if c.CaseRatio != nil {
+		g.caseRatio, g.hasCaseRatio = *c.CaseRatio, true
+	}
+	if c.MaxSymbolFraction != nil {
+		g.maxSymbolFraction, g.hasSymbolFraction = *c.MaxSymbolFraction, true
+	}
+	for class, limit := range []*int{c.MaxLower, c.MaxUpper, c.MaxDigits, c.MaxSymbols} {
+		if limit != nil {
+			g.classMax[class], g.hasClassMax[class] = *limit, true
+		}
+	}
+	return g, nil
+}
+
+// MarshalJSON encodes the generator configuration as JSON.  It returns
+// ErrUnserializableConfig if a BreachChecker or a source of randomness is set,
+// as the decoded generator would silently go without them.
+func (g *Generator) MarshalJSON() ([]byte, error) {
+	if g.breachChecker != nil || g.random != nil {
+		return nil, ErrUnserializableConfig
+	}
+	return json.Marshal(g.ToConfig())
+}
+
+// UnmarshalJSON replaces the generator configuration with the one encoded in
+// data. Unknown fields, trailing data, and invalid values produce an error.
+func (g *Generator) UnmarshalJSON(data []byte) error {
+	var c Config
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&c); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("trailing data after the generator configuration")
+	}
+	gen, err := FromConfig(c)
+	if err != nil {
+		return err
+	}
+	*g = *gen
+	return nil
+}
+
+// validate checks that every field holds a usable value.
+func (c Config) validate() error {
+	if c.RequireLower < 0 || c.RequireUpper < 0 || c.RequireDigits < 0 || c.RequireSymbols < 0 || c.ClassDiversity < 0 {
+		return ErrInvalidConfig
+	}
+	if c.Length < 0 || c.MaxLength < 0 || c.MaxRepeat < 0 || c.MaxSequence < 0 {
+		return ErrInvalidConfig
+	}
+	if c.MaxLength > 0 && c.Length > c.MaxLength {
+		return ErrInvalidConfig
+	}
+	if (c.WithLower || c.RequireLower > 0) && c.LowerLetters == "" {
+		return ErrInvalidConfig
+	}
+	if (c.WithUpper || c.RequireUpper > 0) && c.UpperLetters == "" {
+		return ErrInvalidConfig
+	}
+	if (c.WithDigits || c.RequireDigits > 0) && c.Digits == "" {
+		return ErrInvalidConfig
+	}
+	if (c.WithSymbols || c.RequireSymbols > 0) && c.Symbols == "" {
+		return ErrInvalidConfig
+	}
+
+	for _, set := range c.RequiredSets {
+		if set.Count < 0 {
+			return ErrInvalidConfig
+		}
+	}
+	names := map[string]bool{}
+	for _, set := range c.Charsets {
+		if set.Require < 0 || names[set.Name] {
+			return ErrInvalidConfig
+		}
+		names[set.Name] = true
+	}
+	for _, p := range c.Pins {
+		if p.Index < 0 || !p.Class.valid() {
+			return ErrInvalidConfig
+		}
+	}
+
+	if c.ClassWeights != nil {
+		for _, weight := range c.ClassWeights {
+			if weight < 0 || weight > MaxClassWeight {
+				return ErrInvalidConfig
+			}
+		}
+	}
+	for _, limit := range []*int{c.MaxLower, c.MaxUpper, c.MaxDigits, c.MaxSymbols} {
+		if limit != nil && *limit < 0 {
+			return ErrInvalidConfig
+		}
+	}
+	for _, fraction := range []*float64{c.CaseRatio, c.MaxSymbolFraction} {
+		if fraction != nil && (math.IsNaN(*fraction) || *fraction < 0 || *fraction > 1) {
+			return ErrInvalidConfig
+		}
+	}
+	if math.IsNaN(c.MinEntropy) || c.MinEntropy < 0 {
+		return ErrInvalidConfig
+	}
+
+	if len(c.RotatingClasses) > 0 || c.RotationPeriod != 0 {
+		if len(c.RotatingClasses) == 0 || c.RotationPeriod <= 0 {
+			return ErrInvalidConfig
+		}
+		for _, class := range c.RotatingClasses {
+			if !class.valid() {
+				return ErrInvalidConfig
+			}
+		}
+	}
+	return nil
+}
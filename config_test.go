@@ -0,0 +1,185 @@
+package passwordgen
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerator_JSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round_trip", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().NoAmbiguousCharacters().RequireUpper(2).RequireDigits(3).ExactSymbols(1).WithLower().RequireClassDiversity(3)
+		data, err := json.Marshal(gen)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+
+		restored := &Generator{}
+		if err := json.Unmarshal(data, restored); err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if !restored.Equal(gen) {
+			t.Errorf("expected: %+v, actual: %+v", gen.ToConfig(), restored.ToConfig())
+		}
+
+		pass, err := restored.Generate(12)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		matches := containsSymnbols.FindAllString(pass, -1)
+		count := 0
+		for _, match := range matches {
+			count += len(match)
+		}
+		if count != 1 {
+			t.Errorf("Expected password %s to have exactly 1 symbol character", pass)
+		}
+		if len(containsUpper.FindAllString(pass, -1)) == 0 || len(containsDigits.FindAllString(pass, -1)) == 0 {
+			t.Errorf("password %s does not contain the required characters", pass)
+		}
+	})
+
+	t.Run("constraints", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithDigits().ExcludeCharacters("abc").WithMaxLength(20).WithLength(12).ForbidLeadingDigit().ForbidKeyboardSequences()
+		gen.maxRepeat = 2
+		data, err := json.Marshal(gen)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+
+		restored := &Generator{}
+		if err := json.Unmarshal(data, restored); err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if !restored.Equal(gen) {
+			t.Errorf("expected: %+v, actual: %+v", gen.ToConfig(), restored.ToConfig())
+		}
+
+		pass, err := restored.GenerateDefault()
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if len(pass) != 12 {
+			t.Errorf("expected: %d, actual: %d", 12, len(pass))
+		}
+		if strings.ContainsAny(pass, "abc") {
+			t.Errorf("password %s contains an excluded character", pass)
+		}
+		if pass[0] >= '0' && pass[0] <= '9' {
+			t.Errorf("password %s starts with a digit", pass)
+		}
+		if longestRun(strings.Split(pass, "")) > 2 {
+			t.Errorf("password %s repeats a character more than twice", pass)
+		}
+	})
+
+	t.Run("every_option", func(t *testing.T) {
+		t.Parallel()
+		gens := []*Generator{
+			NewGenerator().ExcludeCharacters("l").RequireLower(1).RequireUpper(1).RequireDigits(1).ExactSymbols(1).
+				RequireClassDiversity(3).RequireFromSet("@#", 1).AddCharset("hex", "0123456789abcdef").RequireCharset("hex", 1).
+				WithEnglishLetterFrequency().WithClassWeights(3, 3, 2, 1).MaxDigits(4).BalancedClasses().WithCaseRatio(0.5).
+				MaxSymbolFraction(0.25).WithMaxAttempts(50).WithMaxLength(64).WithLength(20).WithAutoLength().WithMinEntropy(60).
+				WithEmojiSet([]string{"🔥", "👍🏽"}).ForbidKeyboardSequences().NoSequences(3).NoConsecutiveDuplicates().
+				ForbidLeadingDigit().ForbidBoundarySymbols().StartWithLetter().PinPosition(1, ClassUpper).
+				RequireUniquePerClass().NoCrossClassConfusables().
+				WithTimeSeededClassRequirement([]CharClass{ClassDigit, ClassSymbol}, time.Hour),
+			NewGenerator().WithSymbols().ExactLower(2).ExactUpper(0).ExactDigits(1).ExactCharset("pin", 1),
+		}
+
+		// Every option must be set by one of the generators, so an option added without
+		// being serialized fails the test.
+		unset := map[string]bool{}
+		typ := reflect.TypeOf(Generator{})
+		for i := 0; i < typ.NumField(); i++ {
+			unset[typ.Field(i).Name] = true
+		}
+		for _, name := range []string{"breachChecker", "cache", "random", "now"} {
+			delete(unset, name)
+		}
+		for _, gen := range gens {
+			v := reflect.ValueOf(gen).Elem()
+			for i := 0; i < typ.NumField(); i++ {
+				if !v.Field(i).IsZero() {
+					delete(unset, typ.Field(i).Name)
+				}
+			}
+		}
+		if len(unset) > 0 {
+			t.Errorf("expected every option to be set, unset: %v", unset)
+		}
+
+		for _, gen := range gens {
+			data, err := json.Marshal(gen)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			restored := &Generator{}
+			if err := json.Unmarshal(data, restored); err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if !restored.Equal(gen) {
+				t.Errorf("expected: %+v, actual: %+v", gen.ToConfig(), restored.ToConfig())
+			}
+		}
+	})
+
+	t.Run("unserializable", func(t *testing.T) {
+		t.Parallel()
+		for _, gen := range []*Generator{
+			NewGenerator().WithLower().WithBreachCheck(&fakeBreachChecker{}),
+			NewGenerator().WithLower().WithRand(strings.NewReader("seed")),
+		} {
+			if _, err := json.Marshal(gen); !errors.Is(err, ErrUnserializableConfig) {
+				t.Errorf("expected: %q, actual: %q", ErrUnserializableConfig, err)
+			}
+		}
+	})
+
+	t.Run("trailing_data", func(t *testing.T) {
+		t.Parallel()
+		gen := &Generator{}
+		if err := gen.UnmarshalJSON([]byte(`{"withLower":true,"lowerLetters":"abc"} {}`)); err == nil {
+			t.Error("expected an error for trailing data")
+		}
+	})
+
+	t.Run("unknown_field", func(t *testing.T) {
+		t.Parallel()
+		gen := &Generator{}
+		if err := json.Unmarshal([]byte(`{"withLower":true,"lowerLetters":"abc","withEmoji":true}`), gen); err == nil {
+			t.Error("expected an error for an unknown field")
+		}
+	})
+
+	t.Run("invalid_values", func(t *testing.T) {
+		t.Parallel()
+		for _, data := range []string{
+			`{"lowerLetters":"abc","requireLower":-1}`,
+			`{"withDigits":true,"digits":""}`,
+			`{"lowerLetters":"abc","withLower":true,"maxRepeat":-1}`,
+			`{"lowerLetters":"abc","withLower":true,"length":20,"maxLength":10}`,
+			`{"lowerLetters":"abc","withLower":true,"requiredSets":[{"characters":"@","count":-1}]}`,
+			`{"lowerLetters":"abc","withLower":true,"charsets":[{"name":"hex"},{"name":"hex"}]}`,
+			`{"lowerLetters":"abc","withLower":true,"pins":[{"index":0,"class":7}]}`,
+			`{"lowerLetters":"abc","withLower":true,"classWeights":[1,1,-1,1]}`,
+			`{"lowerLetters":"abc","withLower":true,"classWeights":[1,1,1048577,1]}`,
+			`{"lowerLetters":"abc","withLower":true,"maxLower":-1}`,
+			`{"lowerLetters":"abc","withLower":true,"caseRatio":1.5}`,
+			`{"lowerLetters":"abc","withLower":true,"maxSymbolFraction":-0.1}`,
+			`{"lowerLetters":"abc","withLower":true,"minEntropy":-1}`,
+			`{"lowerLetters":"abc","withLower":true,"rotatingClasses":[0]}`,
+		} {
+			if err := json.Unmarshal([]byte(data), &Generator{}); err != ErrInvalidConfig {
+				t.Errorf("expected: %q, actual: %q", ErrInvalidConfig, err)
+			}
+		}
+	})
+}
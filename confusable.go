@@ -0,0 +1,68 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import "slices"
+
+// crossClassConfusables are the groups of characters from different classes which are easily
+// mistaken for one another.
+var crossClassConfusables = [][]string{
+	{"0", "O"},
+	{"1", "l", "I"},
+	{"5", "S"},
+	{"2", "Z"},
+	{"8", "B"},
+}
+
+// NoCrossClassConfusables ensures characters from different classes which look alike won't
+// be in the password, unlike NoAmbiguousCharacters which only considers each class on its
+// own.  The groups removed are 0 and O, 1, l, and I, 5 and S, 2 and Z, and 8 and B.  A group
+// is only removed when at least two of the classes its characters belong to are enabled, e.g.
+// 0 is still drawn when upper case letters are disabled.
+func (g *Generator) NoCrossClassConfusables() *Generator {
+	g.noCrossClassConfusables = true
+	g.invalidate()
+	return g
+}
+
+// removeConfusables returns the elements of each class without the confusable groups spanning
+// more than one enabled class, when NoCrossClassConfusables is set.
+func (g *Generator) removeConfusables(elements [4][]string) [4][]string {
+	if !g.noCrossClassConfusables {
+		return elements
+	}
+
+	enabled := [4]bool{
+		g.withLower || g.requireLower > 0,
+		g.withUpper || g.requireUpper > 0,
+		g.withDigits || g.requireDigits > 0,
+		g.withSymbols || g.requireSymbols > 0,
+	}
+	var removed []string
+	for _, group := range crossClassConfusables {
+		classes := 0
+		for class, pool := range elements {
+			if enabled[class] && slices.ContainsFunc(group, func(elm string) bool { return slices.Contains(pool, elm) }) {
+				classes++
+			}
+		}
+		if classes > 1 {
+			removed = append(removed, group...)
+		}
+	}
+
+	for class, pool := range elements {
+		elements[class] = slices.DeleteFunc(slices.Clone(pool), func(elm string) bool {
+			return slices.Contains(removed, elm)
+		})
+	}
+	return elements
+}
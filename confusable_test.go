@@ -0,0 +1,48 @@
+package passwordgen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerator_NoCrossClassConfusables(t *testing.T) {
+	t.Parallel()
+
+	t.Run("removed", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithUpper().WithDigits().WithSymbols().NoCrossClassConfusables()
+		for i := 0; i < 200; i++ {
+			pass, err := gen.Generate(32)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if strings.ContainsAny(pass, "0O1lI5S2Z8B") {
+				t.Errorf("password %s contains a cross class confusable character", pass)
+			}
+		}
+	})
+
+	t.Run("single_class", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithDigits().NoCrossClassConfusables()
+		plan, err := gen.Plan(8)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if plan.Pool != Digits {
+			t.Errorf("expected: %q, actual: %q", Digits, plan.Pool)
+		}
+	})
+
+	t.Run("only_spanning_groups", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithDigits().NoCrossClassConfusables()
+		plan, err := gen.Plan(8)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if expected := strings.Replace(LowerLetters, "l", "", 1) + "023456789"; plan.Pool != expected {
+			t.Errorf("expected: %q, actual: %q", expected, plan.Pool)
+		}
+	})
+}
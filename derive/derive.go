@@ -0,0 +1,129 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+// Package derive deterministically derives per-site passwords from a master secret, in the
+// style of LessPass and Master Password, so the library can back a stateless password manager
+// which never stores the passwords it hands out.
+//
+// The master secret is stretched once with PBKDF2-HMAC-SHA256, salted with the name of the
+// user, which makes guessing a weak master secret expensive.  Every site password is then drawn
+// by a passwordgen.Generator from a ChaCha8 stream seeded with HKDF-SHA256 of the stretched key,
+// the site, and a counter, which is incremented to rotate the password of a site.
+//
+// A derived password depends on how the generator consumes randomness, so the passwords of a
+// vault must be derived by the same version of passwordgen and the same generator
+// configuration.
+package derive
+
+import (
+	"crypto/hkdf"
+	"crypto/pbkdf2"
+	"crypto/sha256"
+	"errors"
+	"math/rand/v2"
+	"strconv"
+
+	"github.com/kenXengineering/passwordgen"
+)
+
+// DefaultIterations is the number of PBKDF2 iterations used by New, the number recommended by
+// OWASP for PBKDF2-HMAC-SHA256.
+const DefaultIterations = 600000
+
+// keyLength is the length in bytes of the stretched master key and of the seeds.
+const keyLength = 32
+
+var (
+	// ErrEmptyMasterSecret is the error returned when a Deriver is made without a master
+	// secret
+	ErrEmptyMasterSecret = errors.New("master secret is empty")
+
+	// ErrInvalidIterations is the error returned when a Deriver is made with a non-positive
+	// number of iterations
+	ErrInvalidIterations = errors.New("number of iterations must be positive")
+
+	// ErrEmptySite is the error returned when a password is derived for an empty site
+	ErrEmptySite = errors.New("site is empty")
+
+	// ErrNondeterministicGenerator is the error returned when a password is derived with a
+	// generator which isn't deterministic, see passwordgen.Generator.Deterministic
+	ErrNondeterministicGenerator = errors.New("generator doesn't derive the same password from the same inputs")
+)
+
+// Deriver derives site passwords from a master secret.  A Deriver is safe for concurrent use
+// once configured.
+type Deriver struct {
+	key       []byte
+	generator *passwordgen.Generator
+	length    int
+}
+
+// New returns a Deriver for the master secret of the user, stretched with DefaultIterations
+// PBKDF2 iterations.  Passwords are generated from every default class, with a length of
+// passwordgen.DefaultLength.
+func New(master []byte, user string) (*Deriver, error) {
+	return NewWithIterations(master, user, DefaultIterations)
+}
+
+// NewWithIterations returns a Deriver like New, with the given number of PBKDF2 iterations.
+// Changing the number of iterations changes every derived password.
+func NewWithIterations(master []byte, user string, iterations int) (*Deriver, error) {
+	if len(master) == 0 {
+		return nil, ErrEmptyMasterSecret
+	}
+	if iterations < 1 {
+		return nil, ErrInvalidIterations
+	}
+	key, err := pbkdf2.Key(sha256.New, string(master), []byte("passwordgen/derive\x00"+user), iterations, keyLength)
+	if err != nil {
+		return nil, err
+	}
+	return &Deriver{
+		key:       key,
+		generator: passwordgen.NewGenerator().WithLower().WithUpper().WithDigits().WithSymbols(),
+		length:    passwordgen.DefaultLength,
+	}, nil
+}
+
+// WithGenerator sets the generator which draws the passwords, e.g. to satisfy the character
+// policy of a site.  The generator is copied, changing it afterwards doesn't affect the
+// Deriver.  Its source of randomness is replaced by the derived stream.  Password returns
+// ErrNondeterministicGenerator for a generator which rotates its required class with the time
+// or checks a BreachChecker, as it wouldn't derive the same password every time.
+func (d *Deriver) WithGenerator(g *passwordgen.Generator) *Deriver {
+	d.generator = g.Clone()
+	return d
+}
+
+// WithLength sets the number of characters of the derived passwords.
+func (d *Deriver) WithLength(length int) *Deriver {
+	d.length = length
+	return d
+}
+
+// Password returns the password of the site for the counter, which starts at 1 and is
+// incremented to rotate the password.  The same master secret, user, site, counter, and
+// configuration always derive the same password.
+func (d *Deriver) Password(site string, counter uint32) (string, error) {
+	if site == "" {
+		return "", ErrEmptySite
+	}
+	if !d.generator.Deterministic() {
+		return "", ErrNondeterministicGenerator
+	}
+	seed, err := hkdf.Key(sha256.New, d.key, nil, site+"\x00"+strconv.FormatUint(uint64(counter), 10), keyLength)
+	if err != nil {
+		return "", err
+	}
+	defer passwordgen.Zero(seed)
+
+	stream := rand.NewChaCha8([keyLength]byte(seed))
+	return d.generator.Clone().WithRand(stream).Generate(d.length)
+}
@@ -0,0 +1,143 @@
+package derive
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kenXengineering/passwordgen"
+	"github.com/kenXengineering/passwordgen/breachcheck"
+)
+
+// testIterations keeps the tests fast, the iterations don't change what is tested.
+const testIterations = 1000
+
+func newDeriver(t *testing.T, master, user string) *Deriver {
+	t.Helper()
+
+	d, err := NewWithIterations([]byte(master), user, testIterations)
+	if err != nil {
+		t.Fatalf("expected no error, received %q", err)
+	}
+	return d
+}
+
+func TestDeriver_Password(t *testing.T) {
+	t.Parallel()
+
+	t.Run("golden", func(t *testing.T) {
+		t.Parallel()
+
+		// Pins the derivation, a change here changes the passwords of every existing vault.
+		pass, err := newDeriver(t, "correct horse battery staple", "alice").Password("example.com", 1)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if expected := "+9[V7APuO@ivaNRi"; pass != expected {
+			t.Errorf("expected: %q, actual: %q", expected, pass)
+		}
+	})
+
+	t.Run("deterministic", func(t *testing.T) {
+		t.Parallel()
+
+		a, err := newDeriver(t, "master", "alice").Password("example.com", 1)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		b, err := newDeriver(t, "master", "alice").Password("example.com", 1)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if a != b {
+			t.Errorf("expected: %q, actual: %q", a, b)
+		}
+	})
+
+	t.Run("inputs_change_password", func(t *testing.T) {
+		t.Parallel()
+
+		base, err := newDeriver(t, "master", "alice").Password("example.com", 1)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		for name, d := range map[string]func() (string, error){
+			"master":  func() (string, error) { return newDeriver(t, "master2", "alice").Password("example.com", 1) },
+			"user":    func() (string, error) { return newDeriver(t, "master", "bob").Password("example.com", 1) },
+			"site":    func() (string, error) { return newDeriver(t, "master", "alice").Password("example.org", 1) },
+			"counter": func() (string, error) { return newDeriver(t, "master", "alice").Password("example.com", 2) },
+		} {
+			pass, err := d()
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if pass == base {
+				t.Errorf("expected a different password when the %s changes, received %q", name, pass)
+			}
+		}
+	})
+
+	t.Run("policy", func(t *testing.T) {
+		t.Parallel()
+
+		d := newDeriver(t, "master", "alice").
+			WithGenerator(passwordgen.NewGenerator().WithLower().WithDigits().RequireDigits(4)).
+			WithLength(10)
+		for _, site := range []string{"a.example", "b.example", "c.example"} {
+			pass, err := d.Password(site, 1)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if len(pass) != 10 {
+				t.Errorf("expected: %d, actual: %d", 10, len(pass))
+			}
+			if strings.Trim(pass, "abcdefghijklmnopqrstuvwxyz0123456789") != "" {
+				t.Errorf("expected only lower case letters and digits, received %q", pass)
+			}
+			digits := 0
+			for _, r := range pass {
+				if strings.ContainsRune("0123456789", r) {
+					digits++
+				}
+			}
+			if digits < 4 {
+				t.Errorf("expected at least 4 digits, received %q", pass)
+			}
+		}
+	})
+
+	t.Run("empty_site", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := newDeriver(t, "master", "alice").Password("", 1); !errors.Is(err, ErrEmptySite) {
+			t.Errorf("expected: %q, actual: %q", ErrEmptySite, err)
+		}
+	})
+
+	t.Run("nondeterministic_generator", func(t *testing.T) {
+		t.Parallel()
+
+		base := passwordgen.NewGenerator().WithLower().WithDigits()
+		for name, gen := range map[string]*passwordgen.Generator{
+			"rotation":     base.Clone().WithTimeSeededClassRequirement([]passwordgen.CharClass{passwordgen.ClassDigit}, time.Hour),
+			"breach_check": base.Clone().WithBreachCheck(breachcheck.New()),
+		} {
+			_, err := newDeriver(t, "master", "alice").WithGenerator(gen).Password("example.com", 1)
+			if !errors.Is(err, ErrNondeterministicGenerator) {
+				t.Errorf("%s: expected: %q, actual: %q", name, ErrNondeterministicGenerator, err)
+			}
+		}
+	})
+}
+
+func TestNewWithIterations(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewWithIterations(nil, "alice", testIterations); !errors.Is(err, ErrEmptyMasterSecret) {
+		t.Errorf("expected: %q, actual: %q", ErrEmptyMasterSecret, err)
+	}
+	if _, err := NewWithIterations([]byte("master"), "alice", 0); !errors.Is(err, ErrInvalidIterations) {
+		t.Errorf("expected: %q, actual: %q", ErrInvalidIterations, err)
+	}
+}
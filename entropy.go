@@ -0,0 +1,176 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"unicode/utf8"
+)
+
+// ErrInsufficientEntropy is the error returned when the generator's configuration
+// would produce a password with less entropy than set with WithMinEntropy
+var ErrInsufficientEntropy = errors.New("password entropy is below the minimum")
+
+// ErrInvalidEntropy is the error returned when a password is generated to an
+// entropy target which is negative or not a number
+var ErrInvalidEntropy = errors.New("entropy target must be a non-negative number")
+
+// WithMinEntropy makes Generate refuse to generate passwords with less than bits of entropy,
+// returning ErrInsufficientEntropy instead.  This guards against misconfiguration, e.g. a
+// length 4 password of digits only has roughly 13 bits.  The entropy is a lower bound computed
+// from the configured pools and the requested length, see Entropy.
+func (g *Generator) WithMinEntropy(bits float64) *Generator {
+	g.minEntropy = bits
+	return g
+}
+
+// Entropy returns the entropy in bits of a password generated at the specified length as
+// configured, 0 if the generator can't generate the length, e.g. it is shorter than
+// MinLength.  The entropy is a lower bound computed from the configured pools and isn't
+// limited by WithMinEntropy.  Each position counts its min-entropy, -log2 of the probability of
+// its most likely character, so pools with repeated or weighted characters count the chance of
+// guessing their most likely character rather than their size.
+func (g *Generator) Entropy(length int) float64 {
+	plan, err := g.plan(length)
+	if err != nil {
+		return 0
+	}
+	return g.planEntropy(plan)
+}
+
+// GenerateEntropy will generate a password of the shortest length which reaches minBits of
+// entropy as configured, e.g. GenerateEntropy(80) for at least 80 bits, instead of picking a
+// length.  Lengths the configuration can't generate are skipped.  ErrInsufficientEntropy is
+// returned if no length up to the maximum length reaches minBits, as is the case for a pool of
+// a single character, and the error of the longest length if no length can be generated at
+// all.  ErrInvalidEntropy is returned if minBits is negative or NaN.
+func (g *Generator) GenerateEntropy(minBits float64) (string, error) {
+	if minBits < 0 || math.IsNaN(minBits) {
+		return "", fmt.Errorf("%w: %v", ErrInvalidEntropy, minBits)
+	}
+	previous := -1.0
+	var planErr error
+	for length := max(g.MinLength(), 1); length <= g.maxPasswordLength(); length++ {
+		plan, err := g.plan(length)
+		if err != nil {
+			planErr = err
+			continue
+		}
+		bits := g.planEntropy(plan)
+		if bits >= minBits {
+			return g.Generate(length)
+		}
+		// Longer passwords won't have more entropy if the free positions add none.
+		if bits <= previous {
+			break
+		}
+		previous = bits
+	}
+	if previous < 0 && planErr != nil {
+		return "", planErr
+	}
+	return "", fmt.Errorf("%w: %.1f bits can't be reached", ErrInsufficientEntropy, minBits)
+}
+
+// EstimateEntropy returns the entropy in bits of a password of characters drawn uniformly from
+// a pool of poolSize characters, e.g. the PoolSize of a generator.  This ignores requirements
+// and weights, which Entropy accounts for.
+func EstimateEntropy(password string, poolSize int) float64 {
+	if poolSize < 1 {
+		return 0
+	}
+	return float64(utf8.RuneCountInString(password)) * math.Log2(float64(poolSize))
+}
+
+// validateEntropy checks the plan reaches the entropy set with WithMinEntropy.
+func (g *Generator) validateEntropy(plan *GenerationPlan) error {
+	if g.minEntropy <= 0 {
+		return nil
+	}
+	if bits := g.planEntropy(plan); bits < g.minEntropy {
+		return fmt.Errorf("%w: %.1f bits, %.1f required", ErrInsufficientEntropy, bits, g.minEntropy)
+	}
+	return nil
+}
+
+// planEntropy returns a lower bound of the entropy in bits of a password generated from the
+// plan, the sum of the min-entropy of every position.  The order of the characters is ignored,
+// as are the repeats RequireUniquePerClass forbids, so the actual entropy is higher.
+func (g *Generator) planEntropy(plan *GenerationPlan) float64 {
+	bits := 0.0
+	for _, class := range g.classes() {
+		if class.require > 0 {
+			bits += float64(class.require) * g.requiredEntropy(class.class, class.elements)
+		}
+	}
+	for _, set := range g.setRequirements() {
+		if set.n > 0 {
+			bits += float64(set.n) * uniformMinEntropy(set.elements)
+		}
+	}
+
+	// Any candidate class may be picked, count each position as drawn from the weakest.
+	if plan.Diversity > 0 {
+		weakest := math.Inf(1)
+		for _, candidate := range plan.candidates {
+			weakest = math.Min(weakest, uniformMinEntropy(candidate))
+		}
+		bits += float64(plan.Diversity) * weakest
+	}
+
+	if plan.Free > 0 {
+		pools := g.pools()
+		perPosition := g.fillEntropy(plan.fill, pools.weighted)
+		if g.hasSymbolFraction {
+			perPosition = math.Min(perPosition, g.fillEntropy(pools.fillNoSymbols, pools.weightedNoSymbols))
+		}
+		bits += float64(plan.Free) * perPosition
+	}
+	return bits
+}
+
+// requiredEntropy returns the min-entropy in bits of a single required character of class drawn
+// from elements, weighted by English letter frequency for letters if
+// WithEnglishLetterFrequency is set, as letterElement draws them.
+func (g *Generator) requiredEntropy(class CharClass, elements []string) float64 {
+	if g.englishFrequency && (class == ClassLower || class == ClassUpper) {
+		var pool weightedPool
+		pool.addLetters(elements, int64(len(elements))*baseWeight)
+		return pool.minEntropy()
+	}
+	return uniformMinEntropy(elements)
+}
+
+// fillEntropy returns the min-entropy in bits of a single character drawn from the fill pool.
+func (g *Generator) fillEntropy(fill []string, weighted *weightedPool) float64 {
+	if !g.weightedFill() {
+		return uniformMinEntropy(fill)
+	}
+	return weighted.minEntropy()
+}
+
+// uniformMinEntropy returns the min-entropy in bits of a single element drawn uniformly from
+// elements.  A repeated element is more likely to be drawn than the others, so this is below
+// log2 of the number of distinct elements if any is repeated.
+func uniformMinEntropy(elements []string) float64 {
+	if len(elements) == 0 {
+		return 0
+	}
+	counts := map[string]int{}
+	most := 0
+	for _, elm := range elements {
+		counts[elm]++
+		most = max(most, counts[elm])
+	}
+	return math.Log2(float64(len(elements)) / float64(most))
+}
@@ -0,0 +1,207 @@
+package passwordgen
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestGenerator_WithMinEntropy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("insufficient", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithDigits().WithMinEntropy(60)
+		if _, err := gen.Generate(4); !errors.Is(err, ErrInsufficientEntropy) {
+			t.Errorf("expected: %q, actual: %q", ErrInsufficientEntropy, err)
+		}
+		if _, err := gen.Plan(4); !errors.Is(err, ErrInsufficientEntropy) {
+			t.Errorf("expected: %q, actual: %q", ErrInsufficientEntropy, err)
+		}
+	})
+
+	t.Run("sufficient", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithUpper().WithDigits().RequireSymbols(1).WithMinEntropy(60)
+		if _, err := gen.Generate(16); err != nil {
+			t.Errorf("expected no error, received %q", err)
+		}
+	})
+
+	t.Run("boundary", func(t *testing.T) {
+		t.Parallel()
+		// 18 digits have 18 * log2(10) ~ 59.8 bits, 19 digits have ~63.1 bits.
+		gen := NewGenerator().WithDigits().WithMinEntropy(60)
+		if _, err := gen.Generate(18); !errors.Is(err, ErrInsufficientEntropy) {
+			t.Errorf("expected: %q, actual: %q", ErrInsufficientEntropy, err)
+		}
+		if _, err := gen.Generate(19); err != nil {
+			t.Errorf("expected no error, received %q", err)
+		}
+	})
+
+	t.Run("weighted", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithDigits().WithClassWeights(0, 0, 1, 0)
+		plan, err := gen.Plan(10)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if bits := gen.planEntropy(plan); math.Abs(bits-10*math.Log2(10)) > 0.01 {
+			t.Errorf("expected: %.2f bits, actual: %.2f", 10*math.Log2(10), bits)
+		}
+	})
+}
+
+func TestGenerator_Entropy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("uniform", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithUpper().WithDigits()
+		expected := 12 * math.Log2(62)
+		if bits := gen.Entropy(12); math.Abs(bits-expected) > 1e-9 {
+			t.Errorf("expected: %f, actual: %f", expected, bits)
+		}
+		if bits := EstimateEntropy("abcdefghijkl", gen.PoolSize()); math.Abs(bits-expected) > 1e-9 {
+			t.Errorf("expected: %f, actual: %f", expected, bits)
+		}
+	})
+
+	t.Run("required", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().RequireDigits(2)
+		expected := 2*math.Log2(10) + 6*math.Log2(36)
+		if bits := gen.Entropy(8); math.Abs(bits-expected) > 1e-9 {
+			t.Errorf("expected: %f, actual: %f", expected, bits)
+		}
+	})
+
+	t.Run("english_letter_frequency", func(t *testing.T) {
+		t.Parallel()
+		for name, gen := range map[string]func() *Generator{
+			"required": func() *Generator { return NewGenerator().RequireLower(8) },
+			"free":     func() *Generator { return NewGenerator().WithLower() },
+			"mixed":    func() *Generator { return NewGenerator().WithLower().RequireUpper(4).RequireLower(2) },
+		} {
+			uniform := gen().Entropy(8)
+			weighted := gen().WithEnglishLetterFrequency().Entropy(8)
+			if weighted >= uniform {
+				t.Errorf("expected the %s weighted entropy to be below %f, actual: %f", name, uniform, weighted)
+			}
+		}
+		// e is the most likely letter at about 12.7%, about 2.98 bits per letter.
+		if bits := NewGenerator().RequireLower(8).WithEnglishLetterFrequency().Entropy(8); bits < 8*2.9 || bits > 8*3.05 {
+			t.Errorf("expected about %f, actual: %f", 8*2.98, bits)
+		}
+	})
+
+	t.Run("repeated_elements", func(t *testing.T) {
+		t.Parallel()
+		// a is drawn two times out of three, guessing it takes log2(3/2) bits per position.
+		expected := 4 * math.Log2(1.5)
+		if bits := NewGenerator().RequireFromSet("aab", 4).Entropy(4); math.Abs(bits-expected) > 1e-9 {
+			t.Errorf("expected: %f, actual: %f", expected, bits)
+		}
+	})
+
+	t.Run("ignores_min_entropy", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithDigits().WithMinEntropy(60)
+		if bits := gen.Entropy(4); bits <= 0 {
+			t.Errorf("expected the entropy of 4 digits, actual: %f", bits)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+		if bits := NewGenerator().RequireDigits(4).Entropy(2); bits != 0 {
+			t.Errorf("expected: %f, actual: %f", 0.0, bits)
+		}
+		if bits := NewGenerator().Entropy(8); bits != 0 {
+			t.Errorf("expected: %f, actual: %f", 0.0, bits)
+		}
+	})
+}
+
+func TestEstimateEntropy(t *testing.T) {
+	t.Parallel()
+
+	if bits := EstimateEntropy("äöü", 8); bits != 9 {
+		t.Errorf("expected: %f, actual: %f", 9.0, bits)
+	}
+	if bits := EstimateEntropy("", 8); bits != 0 {
+		t.Errorf("expected: %f, actual: %f", 0.0, bits)
+	}
+	if bits := EstimateEntropy("abc", 0); bits != 0 {
+		t.Errorf("expected: %f, actual: %f", 0.0, bits)
+	}
+}
+
+func TestGenerator_GenerateEntropy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("shortest_length", func(t *testing.T) {
+		t.Parallel()
+		// 19 digits are the shortest to reach 60 bits, see the boundary test of WithMinEntropy.
+		pass, err := NewGenerator().WithDigits().GenerateEntropy(60)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if len(pass) != 19 {
+			t.Errorf("expected: %d, actual: %d", 19, len(pass))
+		}
+	})
+
+	t.Run("requirements", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithUpper().RequireDigits(2).RequireSymbols(2)
+		pass, err := gen.GenerateEntropy(80)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if bits := gen.Entropy(len(pass)); bits < 80 {
+			t.Errorf("expected at least 80 bits, actual: %f", bits)
+		}
+		if bits := gen.Entropy(len(pass) - 1); bits >= 80 {
+			t.Errorf("expected %d characters to have fewer than 80 bits, actual: %f", len(pass)-1, bits)
+		}
+	})
+
+	t.Run("skips_infeasible_lengths", func(t *testing.T) {
+		t.Parallel()
+		// Lengths 3 and 4 can't place 3 symbols away from the boundaries.
+		gen := NewGenerator().WithLower().ExactSymbols(3).ForbidBoundarySymbols()
+		pass, err := gen.GenerateEntropy(1)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if len(pass) != 5 {
+			t.Errorf("expected: %d, actual: %d", 5, len(pass))
+		}
+	})
+
+	t.Run("invalid_target", func(t *testing.T) {
+		t.Parallel()
+		for _, bits := range []float64{-1, math.NaN()} {
+			if _, err := NewGenerator().WithLower().GenerateEntropy(bits); !errors.Is(err, ErrInvalidEntropy) {
+				t.Errorf("expected: %q, actual: %q", ErrInvalidEntropy, err)
+			}
+		}
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLocaleDigits("7")
+		if _, err := gen.GenerateEntropy(10); !errors.Is(err, ErrInsufficientEntropy) {
+			t.Errorf("expected: %q, actual: %q", ErrInsufficientEntropy, err)
+		}
+	})
+
+	t.Run("no_characters", func(t *testing.T) {
+		t.Parallel()
+		if _, err := NewGenerator().GenerateEntropy(10); !errors.Is(err, ErrNoCharactersSpecified) {
+			t.Errorf("expected: %q, actual: %q", ErrNoCharactersSpecified, err)
+		}
+	})
+}
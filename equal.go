@@ -0,0 +1,46 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import (
+	"reflect"
+	"slices"
+)
+
+// Equal reports whether g and other are configured identically, i.e. they have the same
+// character pools, enabled classes, requirements, and options.  Generators which are Equal
+// generate passwords from identical constraints.
+func (g *Generator) Equal(other *Generator) bool {
+	if g == nil || other == nil {
+		return g == other
+	}
+
+	if !slices.Equal(g.rotatingClasses, other.rotatingClasses) ||
+		!slices.Equal(g.emojis, other.emojis) ||
+		!slices.EqualFunc(g.requiredSets, other.requiredSets, func(a, b setRequirement) bool {
+			return a.n == b.n && slices.Equal(a.elements, b.elements)
+		}) ||
+		!slices.EqualFunc(g.charsets, other.charsets, func(a, b charset) bool {
+			return a.name == b.name && slices.Equal(a.elements, b.elements) && a.added == b.added &&
+				a.with == b.with && a.require == b.require && a.exact == b.exact
+		}) {
+		return false
+	}
+
+	// Compare the remaining fields as a whole, so new options are covered without listing
+	// them here.  The cache, clock, and source of randomness don't affect the configuration.
+	a, b := *g, *other
+	for _, gen := range []*Generator{&a, &b} {
+		gen.rotatingClasses, gen.emojis, gen.requiredSets, gen.charsets = nil, nil, nil, nil
+		gen.cache, gen.now, gen.random = nil, nil, nil
+	}
+	return reflect.DeepEqual(a, b)
+}
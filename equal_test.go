@@ -0,0 +1,50 @@
+package passwordgen
+
+import "testing"
+
+func TestGenerator_Equal(t *testing.T) {
+	t.Parallel()
+
+	t.Run("clone", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().NoAmbiguousCharacters().RequireUpper(2).ExactDigits(3).WithLower().
+			RequireFromSet("@#$", 1).WithEmojiSet([]string{"🔥"})
+		if !gen.Equal(gen.Clone()) {
+			t.Errorf("expected generator to equal its clone")
+		}
+		if _, err := gen.Generate(16); err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if !gen.Equal(gen.Clone()) {
+			t.Errorf("expected generator to equal its clone after generating")
+		}
+	})
+
+	t.Run("changed", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().RequireUpper(2).WithLower()
+		for name, change := range map[string]func(*Generator){
+			"pool":        func(g *Generator) { g.NoAmbiguousLetters() },
+			"with":        func(g *Generator) { g.WithDigits() },
+			"require":     func(g *Generator) { g.RequireUpper(3) },
+			"exact":       func(g *Generator) { g.ExactUpper(2) },
+			"set":         func(g *Generator) { g.RequireFromSet("@", 1) },
+			"max_attempt": func(g *Generator) { g.WithMaxAttempts(5) },
+		} {
+			clone := gen.Clone()
+			change(clone)
+			if gen.Equal(clone) || clone.Equal(gen) {
+				t.Errorf("expected generators to be unequal after changing %s", name)
+			}
+		}
+	})
+
+	t.Run("independent", func(t *testing.T) {
+		t.Parallel()
+		a := NewGenerator().WithLower().WithDigits().RequireSymbols(1)
+		b := NewGenerator().RequireSymbols(1).WithDigits().WithLower()
+		if !a.Equal(b) {
+			t.Errorf("expected generators built in a different order to be equal")
+		}
+	})
+}
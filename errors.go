@@ -0,0 +1,52 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import "fmt"
+
+// LengthError is the error returned when the positions reserved by a generator's requirements
+// don't fit in the requested password length.  It matches ErrExceedsTotalLength with
+// errors.Is.
+type LengthError struct {
+	// Required is the number of positions reserved by requirements.
+	Required int
+
+	// Requested is the requested password length.
+	Requested int
+}
+
+func (e *LengthError) Error() string {
+	return fmt.Sprintf("%s: %d required, %d requested", ErrExceedsTotalLength, e.Required, e.Requested)
+}
+
+// Is reports whether target is ErrExceedsTotalLength.
+func (e *LengthError) Is(target error) bool {
+	return target == ErrExceedsTotalLength
+}
+
+// NoCharactersError is the error returned when a generator has positions to fill but no
+// characters to fill them with.  It matches ErrNoCharactersSpecified with errors.Is.
+type NoCharactersError struct {
+	// Unfilled is the number of positions which have no characters to be drawn from.
+	Unfilled int
+
+	// Requested is the requested password length.
+	Requested int
+}
+
+func (e *NoCharactersError) Error() string {
+	return fmt.Sprintf("%s: %d of %d positions can't be filled", ErrNoCharactersSpecified, e.Unfilled, e.Requested)
+}
+
+// Is reports whether target is ErrNoCharactersSpecified.
+func (e *NoCharactersError) Is(target error) bool {
+	return target == ErrNoCharactersSpecified
+}
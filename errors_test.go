@@ -0,0 +1,53 @@
+package passwordgen
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLengthError(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewGenerator().RequireDigits(5).RequireLower(5).Generate(8)
+	if !errors.Is(err, ErrExceedsTotalLength) {
+		t.Fatalf("expected: %q, actual: %q", ErrExceedsTotalLength, err)
+	}
+	var lengthErr *LengthError
+	if !errors.As(err, &lengthErr) {
+		t.Fatalf("expected a *LengthError, received %T", err)
+	}
+	if lengthErr.Required != 10 || lengthErr.Requested != 8 {
+		t.Errorf("expected: 10 required, 8 requested, actual: %d required, %d requested", lengthErr.Required, lengthErr.Requested)
+	}
+	if errors.Is(err, ErrNoCharactersSpecified) {
+		t.Errorf("expected %q not to match %q", err, ErrNoCharactersSpecified)
+	}
+}
+
+func TestNoCharactersError(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		gen      *Generator
+		unfilled int
+	}{
+		{"no_classes", NewGenerator(), 8},
+		{"exact_only", NewGenerator().ExactDigits(2), 6},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := tc.gen.Generate(8)
+			if !errors.Is(err, ErrNoCharactersSpecified) {
+				t.Fatalf("expected: %q, actual: %q", ErrNoCharactersSpecified, err)
+			}
+			var noCharsErr *NoCharactersError
+			if !errors.As(err, &noCharsErr) {
+				t.Fatalf("expected a *NoCharactersError, received %T", err)
+			}
+			if noCharsErr.Unfilled != tc.unfilled || noCharsErr.Requested != 8 {
+				t.Errorf("expected: %d of 8 unfilled, actual: %d of %d", tc.unfilled, noCharsErr.Unfilled, noCharsErr.Requested)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidExtendLength is the error returned when a password is extended to
+// a length which isn't greater than its current length
+var ErrInvalidExtendLength = errors.New("new length must be greater than the existing password length")
+
+// Extend will lengthen the existing password to newLength characters, appending characters
+// drawn from the generator's pool as configured.  The existing password is kept as is at the
+// start of the result, the requirements aren't applied to the appended characters.
+func (g *Generator) Extend(existing string, newLength int) (string, error) {
+	current := utf8.RuneCountInString(existing)
+	if newLength <= current {
+		return "", ErrInvalidExtendLength
+	}
+	if newLength > g.maxPasswordLength() {
+		return "", ErrExceedsMaxLength
+	}
+
+	pools := g.pools()
+	extra := newLength - current
+	if len(pools.fill) == 0 {
+		return "", &NoCharactersError{Unfilled: extra, Requested: newLength}
+	}
+
+	buffer := strings.Builder{}
+	buffer.WriteString(existing)
+	for i := 0; i < extra; i++ {
+		elm, err := g.fillElement(pools)
+		if err != nil {
+			return "", err
+		}
+		buffer.WriteString(elm)
+	}
+	return buffer.String(), nil
+}
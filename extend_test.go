@@ -0,0 +1,56 @@
+package passwordgen
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestGenerator_Extend(t *testing.T) {
+	t.Parallel()
+
+	t.Run("extended", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithDigits()
+		existing := "abcDEF!"
+		pass, err := gen.Extend(existing, 16)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if !strings.HasPrefix(pass, existing) {
+			t.Errorf("expected password %s to start with %s", pass, existing)
+		}
+		if len(pass) != 16 {
+			t.Errorf("expected password %s to be 16 characters long", pass)
+		}
+		if strings.Trim(pass[len(existing):], Digits) != "" {
+			t.Errorf("expected the appended characters of %s to be digits", pass)
+		}
+	})
+
+	t.Run("multibyte", func(t *testing.T) {
+		t.Parallel()
+		pass, err := NewGenerator().WithLower().Extend("héllo", 8)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if n := utf8.RuneCountInString(pass); n != 8 {
+			t.Errorf("expected: 8 characters, actual: %d in %s", n, pass)
+		}
+	})
+
+	t.Run("not_longer", func(t *testing.T) {
+		t.Parallel()
+		if _, err := NewGenerator().WithLower().Extend("abcdef", 6); err != ErrInvalidExtendLength {
+			t.Errorf("expected: %q, actual: %q", ErrInvalidExtendLength, err)
+		}
+	})
+
+	t.Run("no_characters", func(t *testing.T) {
+		t.Parallel()
+		if _, err := NewGenerator().Extend("abcdef", 8); !errors.Is(err, ErrNoCharactersSpecified) {
+			t.Errorf("expected: %q, actual: %q", ErrNoCharactersSpecified, err)
+		}
+	})
+}
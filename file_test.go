@@ -0,0 +1,65 @@
+package passwordgen
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerator_GenerateToFile(t *testing.T) {
+	t.Parallel()
+
+	t.Run("written", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "passwords.txt")
+		if err := NewGenerator().WithLower().WithDigits().GenerateToFile(path, 16, 25); err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if perm := info.Mode().Perm(); perm != 0600 {
+			t.Errorf("expected: %v, actual: %v", os.FileMode(0600), perm)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+		if len(lines) != 25 {
+			t.Errorf("expected: 25 lines, actual: %d", len(lines))
+		}
+		for _, line := range lines {
+			if len(line) != 16 {
+				t.Errorf("expected password %s to be 16 characters long", line)
+			}
+		}
+	})
+
+	t.Run("no_partial_file", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		path := filepath.Join(dir, "passwords.txt")
+		if err := NewGenerator().GenerateToFile(path, 16, 25); !errors.Is(err, ErrNoCharactersSpecified) {
+			t.Errorf("expected: %q, actual: %q", ErrNoCharactersSpecified, err)
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if len(entries) != 0 {
+			t.Errorf("expected no files to be left behind, found %d", len(entries))
+		}
+	})
+
+	t.Run("missing_directory", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "missing", "passwords.txt")
+		if err := NewGenerator().WithLower().GenerateToFile(path, 16, 1); err == nil {
+			t.Errorf("expected an error creating %s", path)
+		}
+	})
+}
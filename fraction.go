@@ -0,0 +1,65 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import "math"
+
+// MaxSymbolFraction limits the symbols in the generated password to at most f of its length,
+// e.g. 0.2 allows 3 symbols in a 16 character password.  Once the limit is reached the
+// remaining positions are filled from the other enabled classes.  Symbols guaranteed by
+// RequireSymbols or ExactSymbols take precedence, the required minimum is honored even if it
+// exceeds the fraction.  Generate returns ErrInvalidConfig unless 0 <= f <= 1.
+func (g *Generator) MaxSymbolFraction(f float64) *Generator {
+	g.maxSymbolFraction = f
+	g.hasSymbolFraction = true
+	return g
+}
+
+// validateSymbolFraction sets the symbol limit of the plan, and checks the positions above it
+// can be filled without symbols.
+func (g *Generator) validateSymbolFraction(plan *GenerationPlan) error {
+	if !g.hasSymbolFraction {
+		return nil
+	}
+	f := g.maxSymbolFraction
+	if math.IsNaN(f) || f < 0 || f > 1 {
+		return ErrInvalidConfig
+	}
+
+	// Allow for rounding errors so e.g. 0.29 of 100 characters allows 29 symbols.
+	plan.symbolCap = int(math.Floor(f*float64(plan.Length) + 1e-9))
+	if len(g.pools().fillNoSymbols) > 0 {
+		return nil
+	}
+	if fillable := max(plan.symbolCap-plan.Symbols, 0); plan.Free > fillable {
+		return &NoCharactersError{Unfilled: plan.Free - fillable, Requested: plan.Length}
+	}
+	return nil
+}
+
+// WithCaseRatio makes roughly upperFraction of the letters which fill the password upper case,
+// e.g. 0.5 for half upper case letters, when both lower and upper case letters are enabled.
+// Digits and symbols keep their share of the password, and required letters are placed as
+// usual.  Generate returns ErrInvalidConfig unless 0 <= upperFraction <= 1.
+func (g *Generator) WithCaseRatio(upperFraction float64) *Generator {
+	g.caseRatio = upperFraction
+	g.hasCaseRatio = true
+	g.invalidate()
+	return g
+}
+
+// validateCaseRatio checks the fraction set with WithCaseRatio.
+func (g *Generator) validateCaseRatio() error {
+	if g.hasCaseRatio && (math.IsNaN(g.caseRatio) || g.caseRatio < 0 || g.caseRatio > 1) {
+		return ErrInvalidConfig
+	}
+	return nil
+}
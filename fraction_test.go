@@ -0,0 +1,126 @@
+package passwordgen
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestGenerator_MaxSymbolFraction(t *testing.T) {
+	t.Parallel()
+
+	// countSymbols counts the characters of pass which are in Symbols.
+	countSymbols := func(pass string) int {
+		n := 0
+		for _, r := range pass {
+			if class, _ := classOf(r); class == ClassSymbol {
+				n++
+			}
+		}
+		return n
+	}
+
+	t.Run("capped", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithUpper().WithDigits().WithSymbols().MaxSymbolFraction(0.2)
+		total := 0
+		for i := 0; i < 500; i++ {
+			pass, err := gen.Generate(20)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			n := countSymbols(pass)
+			if n > 4 {
+				t.Errorf("password %s contains %d symbols, expected at most 4", pass, n)
+			}
+			total += n
+		}
+		if total == 0 {
+			t.Errorf("expected symbols to be drawn up to the cap")
+		}
+	})
+
+	t.Run("weighted", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithSymbols().WithClassWeights(1, 0, 0, 9).MaxSymbolFraction(0.25)
+		for i := 0; i < 200; i++ {
+			pass, err := gen.Generate(16)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if n := countSymbols(pass); n > 4 {
+				t.Errorf("password %s contains %d symbols, expected at most 4", pass, n)
+			}
+		}
+	})
+
+	t.Run("required_takes_precedence", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().RequireSymbols(6).MaxSymbolFraction(0.1)
+		for i := 0; i < 50; i++ {
+			pass, err := gen.Generate(20)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if n := countSymbols(pass); n != 6 {
+				t.Errorf("password %s contains %d symbols, expected exactly the 6 required", pass, n)
+			}
+		}
+	})
+
+	t.Run("invalid_fraction", func(t *testing.T) {
+		t.Parallel()
+		for _, f := range []float64{-0.1, 1.5} {
+			if _, err := NewGenerator().WithLower().MaxSymbolFraction(f).Generate(8); err != ErrInvalidConfig {
+				t.Errorf("expected: %q, actual: %q", ErrInvalidConfig, err)
+			}
+		}
+	})
+
+	t.Run("only_symbols", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithSymbols().MaxSymbolFraction(0.5)
+		if _, err := gen.Generate(10); !errors.Is(err, ErrNoCharactersSpecified) {
+			t.Errorf("expected: %q, actual: %q", ErrNoCharactersSpecified, err)
+		}
+		if _, err := gen.MaxSymbolFraction(1).Generate(10); err != nil {
+			t.Errorf("expected no error, received %q", err)
+		}
+	})
+}
+
+func TestGenerator_WithCaseRatio(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ratio", func(t *testing.T) {
+		t.Parallel()
+		for _, target := range []float64{0.5, 0.8, 0.1} {
+			gen := NewGenerator().WithLower().WithUpper().WithDigits().WithCaseRatio(target)
+			upper, letters, digits, total := 0, 0, 0, 0
+			for i := 0; i < 100; i++ {
+				pass, err := gen.Generate(100)
+				if err != nil {
+					t.Fatalf("expected no error, received %q", err)
+				}
+				l, u, d, _, _ := CountClasses(pass)
+				upper, letters, digits, total = upper+u, letters+l+u, digits+d, total+len(pass)
+			}
+			if actual := float64(upper) / float64(letters); math.Abs(actual-target) > 0.03 {
+				t.Errorf("expected an upper case fraction near %.2f, actual: %.3f", target, actual)
+			}
+			// Digits keep their share of 10 in 62.
+			if actual := float64(digits) / float64(total); math.Abs(actual-10.0/62) > 0.03 {
+				t.Errorf("expected a digit fraction near %.3f, actual: %.3f", 10.0/62, actual)
+			}
+		}
+	})
+
+	t.Run("invalid_ratio", func(t *testing.T) {
+		t.Parallel()
+		for _, f := range []float64{-0.5, 2} {
+			if _, err := NewGenerator().WithLower().WithUpper().WithCaseRatio(f).Generate(8); err != ErrInvalidConfig {
+				t.Errorf("expected: %q, actual: %q", ErrInvalidConfig, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import "unicode"
+
+// englishLetterFrequency is the relative frequency of each letter in English text,
+// in hundredths of a percent.
+var englishLetterFrequency = map[rune]int64{
+	'a': 817, 'b': 149, 'c': 278, 'd': 425, 'e': 1270, 'f': 223, 'g': 202,
+	'h': 609, 'i': 697, 'j': 15, 'k': 77, 'l': 403, 'm': 241, 'n': 675,
+	'o': 751, 'p': 193, 'q': 10, 'r': 599, 's': 633, 't': 906, 'u': 276,
+	'v': 98, 'w': 236, 'x': 15, 'y': 197, 'z': 7,
+}
+
+// WithEnglishLetterFrequency biases the selection of letters towards the letters that are
+// common in English text (e, t, a, o, ...), which makes passwords easier to type.
+//
+// This reduces the entropy of every letter from roughly 4.7 bits to roughly 4.2 bits, so it
+// shouldn't be used where maximum entropy is required.  The share of letters versus digits
+// and symbols in the password is unchanged.
+func (g *Generator) WithEnglishLetterFrequency() *Generator {
+	g.englishFrequency = true
+	g.invalidate()
+	return g
+}
+
+// letterElement extracts a random letter from the given letters, weighted by English letter
+// frequency when WithEnglishLetterFrequency is set.
+func (g *Generator) letterElement(letters []string) (string, error) {
+	if !g.englishFrequency {
+		return g.randomElement(letters)
+	}
+	var pool weightedPool
+	pool.addLetters(letters, int64(len(letters))*baseWeight)
+	return pool.pick(g.reader())
+}
+
+// letterFrequency returns the English letter frequency of elm, if it is a letter.
+func letterFrequency(elm string) (int64, bool) {
+	r := []rune(elm)
+	if len(r) != 1 {
+		return 0, false
+	}
+	freq, ok := englishLetterFrequency[unicode.ToLower(r[0])]
+	return freq, ok
+}
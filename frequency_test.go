@@ -0,0 +1,65 @@
+package passwordgen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerator_WithEnglishLetterFrequency(t *testing.T) {
+	t.Parallel()
+
+	t.Run("common_letters_more_frequent", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithEnglishLetterFrequency()
+		counts := map[rune]int{}
+		for i := 0; i < 200; i++ {
+			pass, err := gen.Generate(100)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			for _, r := range pass {
+				counts[r]++
+			}
+		}
+		// e is expected roughly 180 times as often as z, t roughly 90 times as often as q.
+		for _, pair := range [][2]rune{{'e', 'z'}, {'t', 'q'}, {'a', 'j'}} {
+			if counts[pair[0]] <= 5*counts[pair[1]] {
+				t.Errorf("expected %c (%d) to appear far more often than %c (%d)", pair[0], counts[pair[0]], pair[1], counts[pair[1]])
+			}
+		}
+	})
+
+	t.Run("class_share_unchanged", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithDigits().WithEnglishLetterFrequency()
+		digits, total := 0, 0
+		for i := 0; i < 200; i++ {
+			pass, err := gen.Generate(100)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			for _, r := range pass {
+				if strings.ContainsRune(Digits, r) {
+					digits++
+				}
+				total++
+			}
+		}
+		// Digits are 10 of the 36 pool characters.
+		if share := float64(digits) / float64(total); share < 0.25 || share > 0.31 {
+			t.Errorf("expected digits to be roughly 28%% of characters, received %.2f%%", share*100)
+		}
+	})
+
+	t.Run("required_letters", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().RequireUpper(4).WithDigits().WithEnglishLetterFrequency()
+		pass, err := gen.Generate(8)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if len(containsUpper.FindAllString(pass, -1)) == 0 {
+			t.Errorf("password %s does not contain upper cap characters", pass)
+		}
+	})
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MaxPBKDF2Iterations is the most iterations PBKDF2Hasher.Verify accepts from a stored hash,
+// so a hash planted by an attacker can't make verifying it arbitrarily expensive.
+const MaxPBKDF2Iterations = 10000000
+
+// maxHashLength is the longest salt or hash in bytes accepted from a stored hash.
+const maxHashLength = 64
+
+// ErrMalformedHash is the error returned when verifying a password against a
+// hash which isn't in the expected format
+var ErrMalformedHash = errors.New("malformed password hash")
+
+// Hasher turns a password into an encoded hash ready to be stored, such as a bcrypt or PHC
+// string.  PBKDF2Hasher and ScryptHasher are built in.  bcrypt and argon2id need
+// golang.org/x/crypto, which this package doesn't depend on, and are adapted with HasherFunc
+// instead, e.g. bcrypt:
+//
+//	HasherFunc(func(password []byte) (string, error) {
+//		hash, err := bcrypt.GenerateFromPassword(password, 12)
+//		return string(hash), err
+//	})
+//
+// The slice given to Hash is zeroed once it returns, so it must not be retained.
+type Hasher interface {
+	Hash(password []byte) (string, error)
+}
+
+// HasherFunc is a function used as a Hasher.
+type HasherFunc func(password []byte) (string, error)
+
+// Hash returns f(password).
+func (f HasherFunc) Hash(password []byte) (string, error) {
+	return f(password)
+}
+
+// PBKDF2Hasher is a Hasher using PBKDF2-HMAC-SHA256, encoded in the PHC string format, e.g.
+// $pbkdf2-sha256$i=600000$<salt>$<hash> with the salt and hash in unpadded base64.
+type PBKDF2Hasher struct {
+	// Iterations is the number of iterations, the work factor of the hash.
+	Iterations int
+
+	// SaltLength and KeyLength are the sizes in bytes of the random salt and the hash.
+	SaltLength int
+	KeyLength  int
+}
+
+// NewPBKDF2Hasher returns a PBKDF2Hasher with the parameters recommended by OWASP, 600000
+// iterations, a 16 byte salt, and a 32 byte hash.
+func NewPBKDF2Hasher() *PBKDF2Hasher {
+	return &PBKDF2Hasher{Iterations: 600000, SaltLength: 16, KeyLength: 32}
+}
+
+// Hash returns the PHC string of password with a new random salt.
+func (h *PBKDF2Hasher) Hash(password []byte) (string, error) {
+	if h.Iterations < 1 || h.Iterations > MaxPBKDF2Iterations {
+		return "", fmt.Errorf("%w: PBKDF2 iterations must be between 1 and %d", ErrInvalidConfig, MaxPBKDF2Iterations)
+	}
+	if h.SaltLength < 1 || h.SaltLength > maxHashLength || h.KeyLength < 1 || h.KeyLength > maxHashLength {
+		return "", fmt.Errorf("%w: PBKDF2 salt and key lengths must be between 1 and %d", ErrInvalidConfig, maxHashLength)
+	}
+	salt, err := randomBytes(h.SaltLength)
+	if err != nil {
+		return "", err
+	}
+	key := pbkdf2Key(password, salt, h.Iterations, h.KeyLength)
+	return encodePHC("pbkdf2-sha256", fmt.Sprintf("i=%d", h.Iterations), salt, key), nil
+}
+
+// Verify reports whether password matches the PHC string encoded, which was returned by Hash
+// with any parameters up to MaxPBKDF2Iterations.  It returns ErrMalformedHash if encoded isn't
+// a PBKDF2-HMAC-SHA256 PHC string within those limits.
+func (h *PBKDF2Hasher) Verify(password []byte, encoded string) (bool, error) {
+	params, salt, expected, err := decodePHC("pbkdf2-sha256", encoded)
+	if err != nil {
+		return false, err
+	}
+	iterations, err := strconv.Atoi(strings.TrimPrefix(params, "i="))
+	if !strings.HasPrefix(params, "i=") || err != nil || iterations < 1 || iterations > MaxPBKDF2Iterations {
+		return false, ErrMalformedHash
+	}
+	key := pbkdf2Key(password, salt, iterations, len(expected))
+	defer Zero(key)
+	return subtle.ConstantTimeCompare(key, expected) == 1, nil
+}
+
+// pbkdf2Key returns the PBKDF2-HMAC-SHA256 key of password as specified by RFC 8018.  Unlike
+// crypto/pbkdf2, it takes the password as a slice, so the password is never copied into a
+// string which can't be wiped.
+func pbkdf2Key(password, salt []byte, iterations, keyLength int) []byte {
+	prf := hmac.New(sha256.New, password)
+	size := prf.Size()
+	blocks := (keyLength + size - 1) / size
+
+	key := make([]byte, 0, blocks*size)
+	u := make([]byte, 0, size)
+	var counter [4]byte
+	for block := 1; block <= blocks; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		binary.BigEndian.PutUint32(counter[:], uint32(block))
+		prf.Write(counter[:])
+		key = prf.Sum(key)
+
+		t := key[len(key)-size:]
+		u = append(u[:0], t...)
+		for i := 1; i < iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+	}
+	Zero(u)
+	return key[:keyLength]
+}
+
+// encodePHC returns the PHC string of the algorithm id with its parameters, salt, and hash.
+func encodePHC(id, params string, salt, hash []byte) string {
+	return fmt.Sprintf("$%s$%s$%s$%s", id, params,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(hash))
+}
+
+// decodePHC returns the parameters, salt, and hash of the PHC string encoded of the algorithm
+// id, or ErrMalformedHash.  The salt and hash are at most maxHashLength bytes.
+func decodePHC(id, encoded string) (params string, salt, hash []byte, err error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 5 || parts[0] != "" || parts[1] != id {
+		return "", nil, nil, ErrMalformedHash
+	}
+	salt, err = base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil || len(salt) > maxHashLength {
+		return "", nil, nil, ErrMalformedHash
+	}
+	hash, err = base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil || len(hash) == 0 || len(hash) > maxHashLength {
+		return "", nil, nil, ErrMalformedHash
+	}
+	return parts[2], salt, hash, nil
+}
+
+// GenerateHashed will generate a password at the specified length as configured along with its
+// hash by hasher, so provisioning code can store the hash and hand the plaintext to the user in
+// one step.  ErrNilHasher is returned if hasher is nil.
+func (g *Generator) GenerateHashed(length int, hasher Hasher) (password, hash string, err error) {
+	if hasher == nil {
+		return "", "", ErrNilHasher
+	}
+	pass, err := g.GenerateBytes(length)
+	if err != nil {
+		return "", "", err
+	}
+	defer Zero(pass)
+	hash, err = hasher.Hash(pass)
+	if err != nil {
+		return "", "", err
+	}
+	return string(pass), hash, nil
+}
@@ -0,0 +1,161 @@
+package passwordgen
+
+import (
+	"bytes"
+	"crypto/pbkdf2"
+	"crypto/sha256"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGenerator_GenerateHashed(t *testing.T) {
+	t.Parallel()
+
+	t.Run("pbkdf2", func(t *testing.T) {
+		t.Parallel()
+
+		hasher := &PBKDF2Hasher{Iterations: 1000, SaltLength: 16, KeyLength: 32}
+		pass, hash, err := NewGenerator().WithLower().WithDigits().GenerateHashed(20, hasher)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if len(pass) != 20 {
+			t.Errorf("expected: %d, actual: %d", 20, len(pass))
+		}
+		if !strings.HasPrefix(hash, "$pbkdf2-sha256$i=1000$") {
+			t.Errorf("expected a PBKDF2 PHC string, received %q", hash)
+		}
+		ok, err := hasher.Verify([]byte(pass), hash)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if !ok {
+			t.Error("expected the password to match its hash")
+		}
+		ok, err = hasher.Verify([]byte(pass+"x"), hash)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if ok {
+			t.Error("expected a different password not to match the hash")
+		}
+	})
+
+	t.Run("hasher_func", func(t *testing.T) {
+		t.Parallel()
+
+		var given []byte
+		hasher := HasherFunc(func(password []byte) (string, error) {
+			given = password
+			return strings.ToUpper(string(password)), nil
+		})
+		pass, hash, err := NewGenerator().WithLower().GenerateHashed(16, hasher)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if hash != strings.ToUpper(pass) {
+			t.Errorf("expected: %q, actual: %q", strings.ToUpper(pass), hash)
+		}
+		if strings.Trim(string(given), "\x00") != "" {
+			t.Errorf("expected the slice given to the hasher to be zeroed, received %q", given)
+		}
+	})
+
+	t.Run("hasher_error", func(t *testing.T) {
+		t.Parallel()
+
+		errHash := errors.New("hash failed")
+		hasher := HasherFunc(func([]byte) (string, error) { return "", errHash })
+		if _, _, err := NewGenerator().WithLower().GenerateHashed(16, hasher); err != errHash {
+			t.Errorf("expected: %q, actual: %q", errHash, err)
+		}
+	})
+
+	t.Run("nil_hasher", func(t *testing.T) {
+		t.Parallel()
+
+		if _, _, err := NewGenerator().WithLower().GenerateHashed(16, nil); err != ErrNilHasher {
+			t.Errorf("expected: %q, actual: %q", ErrNilHasher, err)
+		}
+	})
+}
+
+func TestPBKDF2Hasher(t *testing.T) {
+	t.Parallel()
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+
+		h := NewPBKDF2Hasher()
+		if h.Iterations != 600000 || h.SaltLength != 16 || h.KeyLength != 32 {
+			t.Errorf("expected the OWASP parameters, received %+v", *h)
+		}
+	})
+
+	t.Run("random_salt", func(t *testing.T) {
+		t.Parallel()
+
+		h := &PBKDF2Hasher{Iterations: 1, SaltLength: 16, KeyLength: 32}
+		a, err := h.Hash([]byte("password"))
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		b, err := h.Hash([]byte("password"))
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if a == b {
+			t.Errorf("expected different hashes, received %q twice", a)
+		}
+	})
+
+	t.Run("invalid_parameters", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := (&PBKDF2Hasher{}).Hash([]byte("password")); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("expected: %q, actual: %q", ErrInvalidConfig, err)
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		t.Parallel()
+
+		for _, encoded := range []string{
+			"",
+			"$2a$12$R9h/cIPz0gi.URNNX3kh2OPST9/PgBkqquzi.Ss7KIUgO2t0jWMUW",
+			"$pbkdf2-sha256$i=x$c2FsdA$aGFzaA",
+			"$pbkdf2-sha256$i=1000$!!$aGFzaA",
+			"$pbkdf2-sha256$i=1000$c2FsdA$",
+			"$pbkdf2-sha256$i=2000000000$c2FsdA$aGFzaA",
+			"$pbkdf2-sha256$c=1000$c2FsdA$aGFzaA",
+			"$pbkdf2-sha256$i=1000$c2FsdA$" + strings.Repeat("A", 88),
+		} {
+			if _, err := NewPBKDF2Hasher().Verify([]byte("password"), encoded); err != ErrMalformedHash {
+				t.Errorf("expected: %q, actual: %q for %q", ErrMalformedHash, err, encoded)
+			}
+		}
+	})
+}
+
+func TestPBKDF2Key(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		password, salt        string
+		iterations, keyLength int
+	}{
+		{"password", "salt", 1, 32},
+		{"password", "salt", 4096, 20},
+		{"passwordPASSWORDpassword", "saltSALTsaltSALTsaltSALTsaltSALTsalt", 4096, 40},
+		{"pass\x00word", "sa\x00lt", 2, 100},
+	} {
+		expected, err := pbkdf2.Key(sha256.New, tc.password, []byte(tc.salt), tc.iterations, tc.keyLength)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if actual := pbkdf2Key([]byte(tc.password), []byte(tc.salt), tc.iterations, tc.keyLength); !bytes.Equal(actual, expected) {
+			t.Errorf("expected: %x, actual: %x", expected, actual)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+// Package limits bounds the requests served by the server and rpc packages, so a single request
+// can't make either of them generate unbounded output.
+package limits
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/kenXengineering/passwordgen"
+)
+
+// MaxSeparatorLength is the most characters of the separator placed between the words of a
+// passphrase.  The separator is repeated between every word of every passphrase, so it bounds
+// the size of a response along with the number of words and passphrases.
+const MaxSeparatorLength = 16
+
+var (
+	// ErrInvalidLength is the error returned when a request asks for passwords longer than its
+	// limit, or a policy requires more characters of a kind than the limit
+	ErrInvalidLength = errors.New("invalid password length")
+
+	// ErrInvalidWords is the error returned when a request asks for passphrases of more words
+	// than its limit
+	ErrInvalidWords = errors.New("invalid number of words")
+
+	// ErrInvalidSeparator is the error returned when a request asks for a separator longer than
+	// MaxSeparatorLength
+	ErrInvalidSeparator = errors.New("invalid passphrase separator")
+)
+
+// Password returns an error wrapping ErrInvalidLength if a password request of length characters
+// or any count of its policy is more than maxLength.  Every count is checked on its own, before
+// the generator adds them up, so counts near the largest int can't overflow into a sum which
+// passes.
+func Password(policy passwordgen.Policy, length, maxLength int) error {
+	if length > maxLength || policy.MinLength > maxLength {
+		return fmt.Errorf("%w: at most %d characters are allowed", ErrInvalidLength, maxLength)
+	}
+	for _, count := range []int{policy.MinLower, policy.MinUpper, policy.MinDigits, policy.MinSymbols, policy.MinLetters, policy.MinClasses} {
+		if count > maxLength {
+			return fmt.Errorf("%w: %d required characters, at most %d are allowed", ErrInvalidLength, count, maxLength)
+		}
+	}
+	return nil
+}
+
+// Passphrase returns an error wrapping ErrInvalidWords if a passphrase request asks for more than
+// maxWords words, or ErrInvalidSeparator if its separator is longer than MaxSeparatorLength
+// characters.
+func Passphrase(words int, separator string, maxWords int) error {
+	if words > maxWords {
+		return fmt.Errorf("%w: %d, at most %d are allowed", ErrInvalidWords, words, maxWords)
+	}
+	if n := utf8.RuneCountInString(separator); n > MaxSeparatorLength {
+		return fmt.Errorf("%w: %d characters, at most %d are allowed", ErrInvalidSeparator, n, MaxSeparatorLength)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package limits
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/kenXengineering/passwordgen"
+)
+
+func TestPassword(t *testing.T) {
+	t.Parallel()
+
+	if err := Password(passwordgen.Policy{MinLength: 32, MinDigits: 32}, 32, 32); err != nil {
+		t.Errorf("expected no error, received %q", err)
+	}
+	for name, test := range map[string]struct {
+		policy passwordgen.Policy
+		length int
+	}{
+		"length":     {passwordgen.Policy{}, 33},
+		"min_length": {passwordgen.Policy{MinLength: 33}, 0},
+		"overflow":   {passwordgen.Policy{MinLower: math.MaxInt/4 + 1, MinUpper: math.MaxInt/4 + 1, MinDigits: math.MaxInt/4 + 1, MinSymbols: math.MaxInt/4 + 1}, 0},
+		"letters":    {passwordgen.Policy{MinLetters: 33}, 0},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if err := Password(test.policy, test.length, 32); !errors.Is(err, ErrInvalidLength) {
+				t.Errorf("expected: %q, actual: %q", ErrInvalidLength, err)
+			}
+		})
+	}
+}
+
+func TestPassphrase(t *testing.T) {
+	t.Parallel()
+
+	if err := Passphrase(8, strings.Repeat("é", MaxSeparatorLength), 8); err != nil {
+		t.Errorf("expected no error, received %q", err)
+	}
+	if err := Passphrase(9, "-", 8); !errors.Is(err, ErrInvalidWords) {
+		t.Errorf("expected: %q, actual: %q", ErrInvalidWords, err)
+	}
+	if err := Passphrase(8, strings.Repeat("-", MaxSeparatorLength+1), 8); !errors.Is(err, ErrInvalidSeparator) {
+		t.Errorf("expected: %q, actual: %q", ErrInvalidSeparator, err)
+	}
+}
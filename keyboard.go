@@ -0,0 +1,140 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import "unicode"
+
+// minKeyboardWalk is the shortest walk rejected by ForbidKeyboardSequences.
+const minKeyboardWalk = 3
+
+// qwertyRows is the US QWERTY layout, unshifted and shifted, from the top row down.
+var qwertyRows = [][2]string{
+	{"`1234567890-=", "~!@#$%^&*()_+"},
+	{"qwertyuiop[]\\", "QWERTYUIOP{}|"},
+	{"asdfghjkl;'", "ASDFGHJKL:\""},
+	{"zxcvbnm,./", "ZXCVBNM<>?"},
+}
+
+// qwertyBelow is, for each row, the offsets from a key's index to the indexes of the keys
+// diagonally below it in the next row.  The rows are staggered, e.g. q sits below 1 and 2.
+var qwertyBelow = [][]int{{-2, -1}, {-1, 0}, {-1, 0}}
+
+// qwertyAdjacency maps each key, shifted or not, to the unshifted keys next to it.
+var qwertyAdjacency = buildQwertyAdjacency()
+
+// buildQwertyAdjacency builds the adjacency map from the rows of the layout.
+func buildQwertyAdjacency() map[rune]map[rune]bool {
+	type key struct{ row, col int }
+	keys := map[key]rune{}
+	for row, layers := range qwertyRows {
+		for col, r := range []rune(layers[0]) {
+			keys[key{row, col}] = r
+		}
+	}
+
+	adjacency := map[rune]map[rune]bool{}
+	link := func(a, b rune) {
+		if adjacency[a] == nil {
+			adjacency[a] = map[rune]bool{}
+		}
+		if adjacency[b] == nil {
+			adjacency[b] = map[rune]bool{}
+		}
+		adjacency[a][b] = true
+		adjacency[b][a] = true
+	}
+	for k, r := range keys {
+		if right, ok := keys[key{k.row, k.col + 1}]; ok {
+			link(r, right)
+		}
+		if k.row < len(qwertyBelow) {
+			for _, offset := range qwertyBelow[k.row] {
+				if below, ok := keys[key{k.row + 1, k.col + offset}]; ok {
+					link(r, below)
+				}
+			}
+		}
+	}
+	return adjacency
+}
+
+// unshifted returns the key which produces r without shift on a US QWERTY keyboard.
+func unshifted(r rune) rune {
+	for _, layers := range qwertyRows {
+		for i, shifted := range []rune(layers[1]) {
+			if shifted == r {
+				return []rune(layers[0])[i]
+			}
+		}
+	}
+	return unicode.ToLower(r)
+}
+
+// keyboardAdjacent returns whether the elements are neighbouring keys on a US QWERTY keyboard.
+func keyboardAdjacent(a, b string) bool {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) != 1 || len(rb) != 1 {
+		return false
+	}
+	return qwertyAdjacency[unshifted(ra[0])][unshifted(rb[0])]
+}
+
+// keyboardWalk returns the length of the longest walk of adjacent keys in the elements.
+func keyboardWalk(elements []string) int {
+	longest, run := 0, 0
+	for i := range elements {
+		if i > 0 && keyboardAdjacent(elements[i-1], elements[i]) {
+			run++
+		} else {
+			run = 1
+		}
+		longest = max(longest, run)
+	}
+	return longest
+}
+
+// sequenceRun returns the length of the longest ascending or descending run of consecutive
+// characters in the elements, such as abcd or 4321.  Letters are compared without case.
+func sequenceRun(elements []string) int {
+	longest, run, step := 0, 0, 0
+	for i := range elements {
+		d, ok := 0, false
+		if i > 0 {
+			d, ok = runeStep(elements[i-1], elements[i])
+		}
+		switch {
+		case ok && run > 1 && d == step:
+			run++
+		case ok:
+			run, step = 2, d
+		default:
+			run = 1
+		}
+		longest = max(longest, run)
+	}
+	return longest
+}
+
+// runeStep returns 1 if b is the character after a, -1 if it's the one before, and false if
+// they aren't consecutive.
+func runeStep(a, b string) (int, bool) {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) != 1 || len(rb) != 1 {
+		return 0, false
+	}
+	switch unicode.ToLower(rb[0]) - unicode.ToLower(ra[0]) {
+	case 1:
+		return 1, true
+	case -1:
+		return -1, true
+	}
+	return 0, false
+}
@@ -0,0 +1,106 @@
+package passwordgen
+
+import "testing"
+
+func TestKeyboardWalk(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		pass     string
+		expected int
+	}{
+		{"qwerty", 6},
+		{"xasdfx", 4},
+		{"1qaz", 4},
+		{"!QAZ", 4},
+		{"zaq!", 4},
+		{"qaqa", 4},
+		{"aqp", 2},
+		{"aaaa", 1},
+		{"", 0},
+	} {
+		if walk := keyboardWalk(split(tc.pass)); walk != tc.expected {
+			t.Errorf("expected walk of %d in %s, actual: %d", tc.expected, tc.pass, walk)
+		}
+	}
+}
+
+func TestGenerator_ForbidKeyboardSequences(t *testing.T) {
+	t.Parallel()
+
+	t.Run("regenerates", func(t *testing.T) {
+		t.Parallel()
+		// Roughly one in five random triples from this pool is a keyboard walk.
+		gen := NewGenerator().WithLower().ForbidKeyboardSequences()
+		gen.lowerLetters = "qwe"
+		for i := 0; i < 50; i++ {
+			pass, err := gen.Generate(4)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if walk := keyboardWalk(split(pass)); walk >= minKeyboardWalk {
+				t.Errorf("password %s contains a keyboard walk", pass)
+			}
+		}
+	})
+
+	t.Run("max_attempts", func(t *testing.T) {
+		t.Parallel()
+		// A walk is all but certain in 40 characters of this pool.
+		gen := NewGenerator().WithLower().ForbidKeyboardSequences().WithMaxAttempts(3)
+		gen.lowerLetters = "qwe"
+		if _, err := gen.Generate(40); err != ErrMaxAttemptsExceeded {
+			t.Errorf("expected: %q, actual: %q", ErrMaxAttemptsExceeded, err)
+		}
+	})
+}
+
+func TestSequenceRun(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		pass     string
+		expected int
+	}{
+		{"abcd", 4},
+		{"4321", 4},
+		{"xAbCy", 3},
+		{"abcba", 3},
+		{"acegi", 1},
+		{"a", 1},
+		{"", 0},
+	} {
+		if run := sequenceRun(split(tc.pass)); run != tc.expected {
+			t.Errorf("expected run of %d in %s, actual: %d", tc.expected, tc.pass, run)
+		}
+	}
+}
+
+func TestGenerator_NoSequences(t *testing.T) {
+	t.Parallel()
+
+	t.Run("regenerates", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithDigits().ExcludeCharacters("056789").NoSequences(2)
+		for i := 0; i < 50; i++ {
+			pass, err := gen.Generate(6)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if run := sequenceRun(split(pass)); run > 2 {
+				t.Errorf("password %s contains a sequence of %d", pass, run)
+			}
+			if walk := keyboardWalk(split(pass)); walk > 2 {
+				t.Errorf("password %s contains a keyboard walk of %d", pass, walk)
+			}
+		}
+	})
+
+	t.Run("max_attempts", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithDigits().ExcludeCharacters("03456789").NoSequences(1).WithMaxAttempts(3)
+		if _, err := gen.Generate(40); err != ErrMaxAttemptsExceeded {
+			t.Errorf("expected: %q, actual: %q", ErrMaxAttemptsExceeded, err)
+		}
+	})
+}
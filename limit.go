@@ -0,0 +1,170 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import (
+	"fmt"
+	"slices"
+)
+
+// MaxLower ensures at most n lower case letters will be in the password.  Once n have been
+// drawn the rest of the password is filled from the other pools, so Generate returns
+// ErrNoCharactersSpecified when they can't fill it and ErrInvalidConfig when more lower case
+// letters are required than n allows.
+func (g *Generator) MaxLower(n int) *Generator {
+	return g.maxClass(ClassLower, n)
+}
+
+// MaxUpper ensures at most n upper case letters will be in the password.  See MaxLower.
+func (g *Generator) MaxUpper(n int) *Generator {
+	return g.maxClass(ClassUpper, n)
+}
+
+// MaxDigits ensures at most n digits will be in the password.  See MaxLower.
+func (g *Generator) MaxDigits(n int) *Generator {
+	return g.maxClass(ClassDigit, n)
+}
+
+// MaxSymbols ensures at most n symbols will be in the password, e.g. to keep it easy to type on
+// mobile keyboards.  See MaxLower.
+func (g *Generator) MaxSymbols(n int) *Generator {
+	return g.maxClass(ClassSymbol, n)
+}
+
+// maxClass sets the most characters of class allowed in the password.
+func (g *Generator) maxClass(class CharClass, n int) *Generator {
+	g.classMax[class] = n
+	g.hasClassMax[class] = true
+	return g
+}
+
+// excludedByMax returns whether the maximum of class allows no characters of it at all.
+func (g *Generator) excludedByMax(class CharClass) bool {
+	return g.hasClassMax[class] && g.classMax[class] == 0
+}
+
+// validateClassMax sets the class limits of the plan, including the symbol limit of
+// MaxSymbolFraction, and checks the requirements fit within them and the positions above them
+// can be filled.
+func (g *Generator) validateClassMax(plan *GenerationPlan) error {
+	required := [4]int{plan.Lower, plan.Upper, plan.Digits, plan.Symbols}
+	for class, limited := range g.hasClassMax {
+		if !limited {
+			continue
+		}
+		if g.classMax[class] < 0 {
+			return ErrInvalidConfig
+		}
+		if required[class] > g.classMax[class] {
+			return fmt.Errorf("%w: %d %s characters are required but at most %d are allowed",
+				ErrInvalidConfig, required[class], CharClass(class), g.classMax[class])
+		}
+		plan.caps[class], plan.capped[class] = g.classMax[class], true
+	}
+	if g.hasSymbolFraction && (!plan.capped[ClassSymbol] || plan.symbolCap < plan.caps[ClassSymbol]) {
+		plan.caps[ClassSymbol], plan.capped[ClassSymbol] = plan.symbolCap, true
+	}
+
+	if !slices.Contains(g.hasClassMax[:], true) || plan.Free == 0 || len(g.charsetFill()) > 0 {
+		return nil
+	}
+	enabled := [4]bool{g.withLower, g.withUpper, g.withDigits, g.withSymbols}
+	fillable := 0
+	for class, with := range enabled {
+		if !with || len(g.pools().elements[class]) == 0 || (g.hasClassWeights && g.classWeights[class] == 0) {
+			continue
+		}
+		if !plan.capped[class] {
+			return nil
+		}
+		fillable += max(plan.caps[class]-required[class], 0)
+	}
+	// Each class drawn for RequireClassDiversity takes one of the positions below the limits.
+	if unfilled := plan.Free + plan.Diversity - fillable; unfilled > 0 {
+		return &NoCharactersError{Unfilled: unfilled, Requested: plan.Length}
+	}
+	return nil
+}
+
+// full returns which classes have reached their limit in the plan given the counts drawn.
+func (p *GenerationPlan) full(counts [4]int) [4]bool {
+	var full [4]bool
+	for class := range counts {
+		full[class] = p.capped[class] && counts[class] >= p.caps[class]
+	}
+	return full
+}
+
+// fillPools is the fill pool of a password with some classes left out.
+type fillPools struct {
+	fill     []string
+	elements [4][]string
+	weighted *weightedPool
+}
+
+// cappedPools returns the fill pools without the classes which are full.
+func (g *Generator) cappedPools(full [4]bool) *fillPools {
+	pools := g.pools()
+	if full == [4]bool{ClassSymbol: true} {
+		elements := pools.elements
+		elements[ClassSymbol] = nil
+		return &fillPools{fill: pools.fillNoSymbols, elements: elements, weighted: pools.weightedNoSymbols}
+	}
+
+	p := &fillPools{elements: pools.elements}
+	for class, with := range [4]bool{g.withLower, g.withUpper, g.withDigits, g.withSymbols} {
+		if full[class] {
+			p.elements[class] = nil
+			continue
+		}
+		if with && !(g.hasClassWeights && g.classWeights[class] == 0) {
+			p.fill = append(p.fill, p.elements[class]...)
+		}
+	}
+	p.fill = append(p.fill, g.charsetFill()...)
+	p.weighted = g.weightedFillPool(p.elements, g.charsetPools())
+	return p
+}
+
+// countElements returns the number of elements of each class.
+func (g *Generator) countElements(elements []string) [4]int {
+	var counts [4]int
+	for _, elm := range elements {
+		if class, ok := g.elementClass(elm); ok {
+			counts[class]++
+		}
+	}
+	return counts
+}
+
+// elementClass returns the first class whose pool contains elm, and false if none does.
+func (g *Generator) elementClass(elm string) (CharClass, bool) {
+	for class, pool := range g.pools().elements {
+		if slices.Contains(pool, elm) {
+			return CharClass(class), true
+		}
+	}
+	return 0, false
+}
+
+// withinClassMax returns whether the elements have at most the maximum of every class.
+func (g *Generator) withinClassMax(elements []string) bool {
+	if !slices.Contains(g.hasClassMax[:], true) {
+		return true
+	}
+	counts := g.countElements(elements)
+	for class, limited := range g.hasClassMax {
+		if limited && counts[class] > g.classMax[class] {
+			return false
+		}
+	}
+	return true
+}
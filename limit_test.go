@@ -0,0 +1,74 @@
+package passwordgen
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGenerator_MaxClass(t *testing.T) {
+	t.Parallel()
+
+	t.Run("caps_fill", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithUpper().WithDigits().WithSymbols().MaxSymbols(2).MaxDigits(1).RequireSymbols(1)
+		for i := 0; i < 50; i++ {
+			pass, err := gen.Generate(24)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			_, _, digits, symbols, _ := CountClasses(pass)
+			if symbols < 1 || symbols > 2 {
+				t.Errorf("password %s has %d symbols, expected 1 or 2", pass, symbols)
+			}
+			if digits > 1 {
+				t.Errorf("password %s has %d digits, expected at most 1", pass, digits)
+			}
+		}
+	})
+
+	t.Run("every_class", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithUpper().WithDigits().WithSymbols().MaxLower(2).MaxUpper(2).MaxDigits(2).MaxSymbols(2)
+		pass, err := gen.Generate(8)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		lower, upper, digits, symbols, _ := CountClasses(pass)
+		if lower != 2 || upper != 2 || digits != 2 || symbols != 2 {
+			t.Errorf("password %s doesn't have 2 characters of each class", pass)
+		}
+	})
+
+	t.Run("zero_excludes", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithDigits().MaxDigits(0).RequireClassDiversity(1)
+		pass, err := gen.Generate(32)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if _, _, digits, _, _ := CountClasses(pass); digits != 0 {
+			t.Errorf("password %s has %d digits, expected none", pass, digits)
+		}
+	})
+
+	t.Run("self_check", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithSymbols().MaxSymbols(1).MaxSymbolFraction(0.5)
+		if _, err := gen.GenerateChecked(10); err != nil {
+			t.Errorf("expected no error, received %q", err)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+		if _, err := NewGenerator().WithLower().RequireDigits(3).MaxDigits(2).Generate(8); !errors.Is(err, ErrInvalidConfig) {
+			t.Errorf("expected: %q, actual: %q", ErrInvalidConfig, err)
+		}
+		if _, err := NewGenerator().WithLower().MaxLower(-1).Generate(8); err != ErrInvalidConfig {
+			t.Errorf("expected: %q, actual: %q", ErrInvalidConfig, err)
+		}
+		if _, err := NewGenerator().WithLower().WithDigits().MaxLower(3).MaxDigits(3).Generate(8); !errors.Is(err, ErrNoCharactersSpecified) {
+			t.Errorf("expected: %q, actual: %q", ErrNoCharactersSpecified, err)
+		}
+	})
+}
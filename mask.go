@@ -0,0 +1,119 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrInvalidMask is the error returned when a mask has a placeholder which
+// isn't known or a ? at the end
+var ErrInvalidMask = errors.New("invalid mask")
+
+// GenerateWithMask will generate a password from mask, where every occurrence of placeholder
+// is replaced by a random character from the generator's pool, and all other characters are
+// kept as is.  For example GenerateWithMask("INV-####-####", '#') keeps the prefix and the
+// hyphens.  The placeholders are drawn like the free positions of Generate and Extend, from
+// the enabled classes and weighted by WithClassWeights, BalancedClasses, WithCaseRatio, and
+// WithEnglishLetterFrequency.  The Require and Exact counts aren't applied.
+func (g *Generator) GenerateWithMask(mask string, placeholder rune) (string, error) {
+	pools := g.pools()
+	if len(pools.fill) == 0 {
+		return "", ErrNoCharactersSpecified
+	}
+
+	var b strings.Builder
+	for _, r := range mask {
+		if r != placeholder {
+			b.WriteRune(r)
+			continue
+		}
+		elm, err := g.fillElement(pools)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(elm)
+	}
+	return b.String(), nil
+}
+
+// hexElements returns the hexadecimal digits left in the digit pool and the letter pool of
+// class, so the hex placeholders skip ambiguous and excluded characters like the others.
+func hexElements(pools *poolCache, class CharClass) []string {
+	hex := func(elm string) bool { return len(elm) == 1 && strings.Contains("0123456789abcdefABCDEF", elm) }
+	return slices.Concat(filterElements(pools.elements[ClassDigit], hex), filterElements(pools.elements[class], hex))
+}
+
+// GenerateFromMask will generate a password from a hashcat style mask, where every
+// placeholder is replaced by a random character from its class and all other characters are
+// kept as is.  For example ?u?l?l?l?d?d?s gives passwords like Kpwe42! and Acme-?d?d?d?d
+// gives Acme-4821.  The placeholders are:
+//
+//	?l  lower case letters
+//	?u  upper case letters
+//	?d  digits
+//	?s  symbols
+//	?a  lower case letters, upper case letters, digits, and symbols
+//	?h  lower case hexadecimal digits
+//	?H  upper case hexadecimal digits
+//	??  a literal ?
+//
+// The classes use the generator's character sets, with exclusions and ambiguous characters
+// removed, whether or not the class is enabled.  The Require and Exact counts aren't applied.
+func (g *Generator) GenerateFromMask(mask string) (string, error) {
+	pools := g.pools()
+	var b strings.Builder
+	runes := []rune(mask)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '?' {
+			b.WriteRune(runes[i])
+			continue
+		}
+		if i++; i == len(runes) {
+			return "", fmt.Errorf("%w: trailing ?", ErrInvalidMask)
+		}
+
+		var pool []string
+		switch runes[i] {
+		case '?':
+			b.WriteRune('?')
+			continue
+		case 'l':
+			pool = pools.elements[ClassLower]
+		case 'u':
+			pool = pools.elements[ClassUpper]
+		case 'd':
+			pool = pools.elements[ClassDigit]
+		case 's':
+			pool = pools.elements[ClassSymbol]
+		case 'a':
+			pool = slices.Concat(pools.elements[:]...)
+		case 'h':
+			pool = hexElements(pools, ClassLower)
+		case 'H':
+			pool = hexElements(pools, ClassUpper)
+		default:
+			return "", fmt.Errorf("%w: unknown placeholder ?%c", ErrInvalidMask, runes[i])
+		}
+		if len(pool) == 0 {
+			return "", ErrEmptyCharacterSet
+		}
+		elm, err := g.randomElement(pool)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(elm)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,133 @@
+package passwordgen
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGenerator_GenerateWithMask(t *testing.T) {
+	t.Parallel()
+
+	t.Run("literals_preserved", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithDigits()
+		seen := map[string]bool{}
+		for i := 0; i < 20; i++ {
+			pass, err := gen.GenerateWithMask("INV-####-####", '#')
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if !strings.HasPrefix(pass, "INV-") || pass[8] != '-' || len(pass) != 13 {
+				t.Fatalf("password %s does not match the mask", pass)
+			}
+			for _, r := range pass[4:8] + pass[9:] {
+				if !strings.ContainsRune(Digits, r) {
+					t.Errorf("password %s contains %c which is not a digit", pass, r)
+				}
+			}
+			seen[pass] = true
+		}
+		if len(seen) < 2 {
+			t.Errorf("expected placeholders to be randomized, received %d distinct passwords", len(seen))
+		}
+	})
+
+	t.Run("no_placeholders", func(t *testing.T) {
+		t.Parallel()
+		pass, err := NewGenerator().WithLower().GenerateWithMask("fixed", '#')
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if pass != "fixed" {
+			t.Errorf("expected: %q, actual: %q", "fixed", pass)
+		}
+	})
+
+	t.Run("class_weights", func(t *testing.T) {
+		t.Parallel()
+		// A class with a weight of 0 doesn't fill the free positions of Generate, nor the
+		// placeholders of the mask.
+		pass, err := NewGenerator().WithLower().WithDigits().WithClassWeights(1, 0, 0, 0).GenerateWithMask("id-################", '#')
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		for _, r := range pass[3:] {
+			if !strings.ContainsRune(LowerLetters, r) {
+				t.Errorf("password %s contains %c which is not a lower case letter", pass, r)
+			}
+		}
+	})
+
+	t.Run("no_characters", func(t *testing.T) {
+		t.Parallel()
+		if _, err := NewGenerator().GenerateWithMask("##", '#'); !errors.Is(err, ErrNoCharactersSpecified) {
+			t.Errorf("expected: %q, actual: %q", ErrNoCharactersSpecified, err)
+		}
+	})
+}
+
+func TestGenerator_GenerateFromMask(t *testing.T) {
+	t.Parallel()
+
+	t.Run("classes", func(t *testing.T) {
+		t.Parallel()
+		pass, err := NewGenerator().GenerateFromMask("?u?l?l?l?d?d?s?h?H")
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if len(pass) != 9 {
+			t.Fatalf("expected: %d, actual: %d", 9, len(pass))
+		}
+		for i, set := range []string{UpperLetters, LowerLetters, LowerLetters, LowerLetters, Digits, Digits, Symbols, "0123456789abcdef", "0123456789ABCDEF"} {
+			if !strings.ContainsRune(set, rune(pass[i])) {
+				t.Errorf("password %s has %c at %d which is not in %s", pass, pass[i], i, set)
+			}
+		}
+	})
+
+	t.Run("literals", func(t *testing.T) {
+		t.Parallel()
+		pass, err := NewGenerator().GenerateFromMask("Acme-?d?d?d?d??")
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if !strings.HasPrefix(pass, "Acme-") || !strings.HasSuffix(pass, "?") || len(pass) != 10 {
+			t.Errorf("password %s does not match the mask", pass)
+		}
+	})
+
+	t.Run("exclusions", func(t *testing.T) {
+		t.Parallel()
+		pass, err := NewGenerator().NoAmbiguousCharacters().ExcludeCharacters("2345678").GenerateFromMask("?a?a?a?a?a?a?a?a?d?d")
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if strings.ContainsAny(pass, "012345678Il1O") {
+			t.Errorf("password %s contains an excluded character", pass)
+		}
+		if pass[8:] != "99" {
+			t.Errorf("expected: %q, actual: %q", "99", pass[8:])
+		}
+
+		pass, err = NewGenerator().NoAmbiguousCharacters().ExcludeCharacters("abcABC").GenerateFromMask(strings.Repeat("?h?H", 32))
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if strings.Trim(pass, "23456789defDEF") != "" || strings.ContainsAny(pass, "01") {
+			t.Errorf("password %s contains an excluded or ambiguous hex digit", pass)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+		for _, mask := range []string{"?x", "abc?"} {
+			if _, err := NewGenerator().GenerateFromMask(mask); !errors.Is(err, ErrInvalidMask) {
+				t.Errorf("expected: %q, actual: %q", ErrInvalidMask, err)
+			}
+		}
+		if _, err := NewGenerator().ExcludeCharacters(Digits).GenerateFromMask("?d"); err != ErrEmptyCharacterSet {
+			t.Errorf("expected: %q, actual: %q", ErrEmptyCharacterSet, err)
+		}
+	})
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+// Option configures a generator created by New.
+type Option func(*Generator)
+
+// New returns a new generator configured by the given options, applied in order.  It is the
+// equivalent of chaining the generator's methods, for configurations assembled at runtime:
+//
+//	opts := []Option{WithLower(), WithDigits(), RequireDigits(2)}
+//	if readable {
+//		opts = append(opts, NoAmbiguous())
+//	}
+//	pass, err := New(opts...).GenerateDefault()
+func New(opts ...Option) *Generator {
+	g := NewGenerator()
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// Configure returns an option calling the given method of the generator, for the methods
+// without an option of their own, e.g. Configure((*Generator).ForbidLeadingDigit).
+func Configure(method func(*Generator) *Generator) Option {
+	return func(g *Generator) { method(g) }
+}
+
+// WithLength sets the length of the passwords generated by GenerateDefault.
+func WithLength(length int) Option {
+	return func(g *Generator) { g.WithLength(length) }
+}
+
+// WithLower adds lower case letters to the password pool.
+func WithLower() Option {
+	return func(g *Generator) { g.WithLower() }
+}
+
+// WithUpper adds upper case letters to the password pool.
+func WithUpper() Option {
+	return func(g *Generator) { g.WithUpper() }
+}
+
+// WithDigits adds digits to the password pool.
+func WithDigits() Option {
+	return func(g *Generator) { g.WithDigits() }
+}
+
+// WithSymbols adds symbols to the password pool.
+func WithSymbols() Option {
+	return func(g *Generator) { g.WithSymbols() }
+}
+
+// RequireLower guarantees that at least N lower case letters will be in the password.
+func RequireLower(N int) Option {
+	return func(g *Generator) { g.RequireLower(N) }
+}
+
+// RequireUpper guarantees that at least N upper case letters will be in the password.
+func RequireUpper(N int) Option {
+	return func(g *Generator) { g.RequireUpper(N) }
+}
+
+// RequireDigits guarantees that at least N digits will be in the password.
+func RequireDigits(N int) Option {
+	return func(g *Generator) { g.RequireDigits(N) }
+}
+
+// RequireSymbols guarantees that at least N symbols will be in the password.
+func RequireSymbols(N int) Option {
+	return func(g *Generator) { g.RequireSymbols(N) }
+}
+
+// ExactLower guarantees that there are exactly N lower case letters in the password.
+func ExactLower(N int) Option {
+	return func(g *Generator) { g.ExactLower(N) }
+}
+
+// ExactUpper guarantees that there are exactly N upper case letters in the password.
+func ExactUpper(N int) Option {
+	return func(g *Generator) { g.ExactUpper(N) }
+}
+
+// ExactDigits guarantees that there are exactly N digits in the password.
+func ExactDigits(N int) Option {
+	return func(g *Generator) { g.ExactDigits(N) }
+}
+
+// ExactSymbols guarantees that there are exactly N symbols in the password.
+func ExactSymbols(N int) Option {
+	return func(g *Generator) { g.ExactSymbols(N) }
+}
+
+// NoAmbiguous ensures no ambiguous characters will be in the password.
+func NoAmbiguous() Option {
+	return func(g *Generator) { g.NoAmbiguousCharacters() }
+}
+
+// WithCustomSymbols replaces the symbols with the given characters.
+func WithCustomSymbols(chars string) Option {
+	return func(g *Generator) { g.WithCustomSymbols(chars) }
+}
+
+// ExcludeCharacters removes the given characters from every pool.
+func ExcludeCharacters(chars string) Option {
+	return func(g *Generator) { g.ExcludeCharacters(chars) }
+}
@@ -0,0 +1,42 @@
+package passwordgen
+
+import "testing"
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	t.Run("equals_chaining", func(t *testing.T) {
+		t.Parallel()
+		gen := New(WithLower(), WithUpper(), RequireDigits(2), ExactSymbols(1), NoAmbiguous(), WithLength(12))
+		expected := NewGenerator().WithLower().WithUpper().RequireDigits(2).ExactSymbols(1).
+			NoAmbiguousCharacters().WithLength(12)
+		if !gen.Equal(expected) {
+			t.Error("expected the options to configure the generator like the methods")
+		}
+	})
+
+	t.Run("assembled", func(t *testing.T) {
+		t.Parallel()
+		opts := []Option{WithLower(), WithDigits(), WithLength(6)}
+		opts = append(opts, Configure((*Generator).ForbidLeadingDigit))
+		for i := 0; i < 20; i++ {
+			pass, err := New(opts...).GenerateDefault()
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if len(pass) != 6 {
+				t.Errorf("Expected password %s to be 6 characters long", pass)
+			}
+			if containsDigits.MatchString(pass[:1]) {
+				t.Errorf("password %s starts with a digit", pass)
+			}
+		}
+	})
+
+	t.Run("no_options", func(t *testing.T) {
+		t.Parallel()
+		if !New().Equal(NewGenerator()) {
+			t.Error("expected New without options to equal NewGenerator")
+		}
+	})
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultPassphraseSeparator is the separator placed between the words of a passphrase,
+// unless set with WithSeparator.
+const DefaultPassphraseSeparator = "-"
+
+var (
+	// ErrEmptyWordlist is the error returned when a passphrase is generated
+	// without any words to draw from
+	ErrEmptyWordlist = errors.New("wordlist is empty")
+
+	// ErrInvalidWordCount is the error returned when a passphrase is requested
+	// with a non-positive number of words
+	ErrInvalidWordCount = errors.New("number of words must be positive")
+
+	// ErrInvalidPadding is the error returned when a passphrase is padded with a
+	// negative number of digits or symbols
+	ErrInvalidPadding = errors.New("padding must not be negative")
+)
+
+// Capitalization is how the words of a passphrase are capitalized.
+type Capitalization int
+
+const (
+	// CapitalizeNone keeps the words as they are in the wordlist.
+	CapitalizeNone Capitalization = iota
+
+	// CapitalizeTitle capitalizes the first letter of every word, e.g. Correct-Horse-Battery.
+	CapitalizeTitle
+
+	// CapitalizeFirst capitalizes the first letter of the first word, e.g. Correct-horse-battery.
+	CapitalizeFirst
+
+	// CapitalizeRandom capitalizes the first letter of each word with a probability of one
+	// half, e.g. correct-Horse-Battery, adding a bit of entropy per word.
+	CapitalizeRandom
+)
+
+// PassphraseGenerator is used to generate passphrases made of words drawn from a wordlist,
+// e.g. correct-horse-battery-staple.
+type PassphraseGenerator struct {
+	words          []string
+	separator      string
+	capitalization Capitalization
+
+	symbolSeparator  bool
+	separatorSymbols string
+
+	paddingDigits  int
+	paddingSymbols int
+
+	random io.Reader
+}
+
+// NewPassphraseGenerator returns a new passphrase generator drawing words from
+// DefaultWordlist.
+func NewPassphraseGenerator() *PassphraseGenerator {
+	return &PassphraseGenerator{
+		words:            DefaultWordlist,
+		separator:        DefaultPassphraseSeparator,
+		separatorSymbols: Symbols,
+	}
+}
+
+// WithWordlist sets the words passphrases are drawn from.
+func (p *PassphraseGenerator) WithWordlist(words []string) *PassphraseGenerator {
+	p.words = append([]string(nil), words...)
+	return p
+}
+
+// WithSeparator sets the separator placed between words, replacing WithSymbolSeparator.
+func (p *PassphraseGenerator) WithSeparator(sep string) *PassphraseGenerator {
+	p.separator = sep
+	p.symbolSeparator = false
+	return p
+}
+
+// WithSymbolSeparator separates words with a random symbol instead of a fixed separator, e.g.
+// correct@horse$battery, so passphrases satisfy policies requiring a symbol.  Each separator
+// is drawn independently from Symbols, or the set given to WithSeparatorSymbols.
+func (p *PassphraseGenerator) WithSymbolSeparator() *PassphraseGenerator {
+	p.symbolSeparator = true
+	return p
+}
+
+// WithSeparatorSymbols sets the symbols drawn by WithSymbolSeparator.
+func (p *PassphraseGenerator) WithSeparatorSymbols(symbols string) *PassphraseGenerator {
+	p.separatorSymbols = symbols
+	return p
+}
+
+// WithRand sets the source of randomness the words and separators are drawn from, in place of
+// crypto/rand, see Generator.WithRand.  A nil reader restores crypto/rand.
+func (p *PassphraseGenerator) WithRand(r io.Reader) *PassphraseGenerator {
+	p.random = r
+	return p
+}
+
+// Capitalize sets how the words of the passphrase are capitalized, CapitalizeNone by default.
+// Capitalizing every word the same way doesn't add entropy.
+func (p *PassphraseGenerator) Capitalize(mode Capitalization) *PassphraseGenerator {
+	p.capitalization = mode
+	return p
+}
+
+// WithPadding appends random digits followed by random symbols to the passphrase, after a
+// separator, e.g. correct-horse-battery-47#, so passphrases satisfy policies requiring a digit
+// and a symbol without losing their words.  The symbols are drawn from Symbols, or the set
+// given to WithSeparatorSymbols.
+func (p *PassphraseGenerator) WithPadding(digits, symbols int) *PassphraseGenerator {
+	p.paddingDigits = digits
+	p.paddingSymbols = symbols
+	return p
+}
+
+// Generate will generate a passphrase made of the specified number of words.
+func (p *PassphraseGenerator) Generate(words int) (string, error) {
+	if words <= 0 {
+		return "", ErrInvalidWordCount
+	}
+	if len(p.words) == 0 {
+		return "", ErrEmptyWordlist
+	}
+	if p.paddingDigits < 0 || p.paddingSymbols < 0 {
+		return "", ErrInvalidPadding
+	}
+	symbols := split(p.separatorSymbols)
+	if (p.symbolSeparator || p.paddingSymbols > 0) && len(symbols) == 0 {
+		return "", ErrEmptyCharacterSet
+	}
+
+	buffer := strings.Builder{}
+	writeSeparator := func() error {
+		sep := p.separator
+		if p.symbolSeparator {
+			var err error
+			if sep, err = randomElement(p.reader(), symbols); err != nil {
+				return err
+			}
+		}
+		buffer.WriteString(sep)
+		return nil
+	}
+	for i := 0; i < words; i++ {
+		if i > 0 {
+			if err := writeSeparator(); err != nil {
+				return "", err
+			}
+		}
+		word, err := randomElement(p.reader(), p.words)
+		if err != nil {
+			return "", err
+		}
+		if word, err = p.capitalize(i, word); err != nil {
+			return "", err
+		}
+		buffer.WriteString(word)
+	}
+
+	if p.paddingDigits+p.paddingSymbols > 0 {
+		if err := writeSeparator(); err != nil {
+			return "", err
+		}
+	}
+	digits := split(Digits)
+	for _, pad := range []struct {
+		set []string
+		n   int
+	}{{digits, p.paddingDigits}, {symbols, p.paddingSymbols}} {
+		for i := 0; i < pad.n; i++ {
+			elm, err := randomElement(p.reader(), pad.set)
+			if err != nil {
+				return "", err
+			}
+			buffer.WriteString(elm)
+		}
+	}
+	return buffer.String(), nil
+}
+
+// reader returns the source of randomness set with WithRand, or crypto/rand.
+func (p *PassphraseGenerator) reader() io.Reader {
+	if p.random == nil {
+		return rand.Reader
+	}
+	return p.random
+}
+
+// capitalize returns the i-th word of the passphrase capitalized as set with Capitalize.
+func (p *PassphraseGenerator) capitalize(i int, word string) (string, error) {
+	switch {
+	case p.capitalization == CapitalizeTitle, p.capitalization == CapitalizeFirst && i == 0:
+		return title(word), nil
+	case p.capitalization == CapitalizeRandom:
+		return randomElement(p.reader(), []string{word, title(word)})
+	}
+	return word, nil
+}
+
+// title returns the word with its first letter in upper case.
+func title(word string) string {
+	r, size := utf8.DecodeRuneInString(word)
+	return string(unicode.ToUpper(r)) + word[size:]
+}
+
+// GenerateWords will generate a code of count words drawn from wordlist and joined with sep,
+// for callers who want a quick memorable code without configuring a PassphraseGenerator.
+func GenerateWords(count int, wordlist []string, sep string) (string, error) {
+	return NewPassphraseGenerator().WithWordlist(wordlist).WithSeparator(sep).Generate(count)
+}
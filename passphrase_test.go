@@ -0,0 +1,208 @@
+package passwordgen
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var testWords = []string{"correct", "horse", "battery", "staple"}
+
+func TestPassphraseGenerator_Generate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("separator", func(t *testing.T) {
+		t.Parallel()
+		pass, err := NewPassphraseGenerator().WithWordlist(testWords).WithSeparator(" ").Generate(5)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		words := strings.Split(pass, " ")
+		if len(words) != 5 {
+			t.Errorf("expected: 5 words, actual: %d in %s", len(words), pass)
+		}
+		for _, word := range words {
+			if !slices.Contains(testWords, word) {
+				t.Errorf("word %s of %s is not in the wordlist", word, pass)
+			}
+		}
+	})
+
+	t.Run("symbol_separator", func(t *testing.T) {
+		t.Parallel()
+		gen := NewPassphraseGenerator().WithWordlist(testWords).WithSymbolSeparator()
+		for i := 0; i < 20; i++ {
+			pass, err := gen.Generate(4)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			words := strings.FieldsFunc(pass, func(r rune) bool { return strings.ContainsRune(Symbols, r) })
+			if len(words) != 4 {
+				t.Fatalf("expected: 4 words, actual: %d in %s", len(words), pass)
+			}
+			if n := len(pass) - len(strings.Join(words, "")); n != 3 {
+				t.Errorf("expected: 3 symbol separators, actual: %d in %s", n, pass)
+			}
+		}
+	})
+
+	t.Run("separator_symbols", func(t *testing.T) {
+		t.Parallel()
+		pass, err := NewPassphraseGenerator().WithWordlist([]string{"word"}).WithSymbolSeparator().WithSeparatorSymbols("@").Generate(3)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if pass != "word@word@word" {
+			t.Errorf("expected: %q, actual: %q", "word@word@word", pass)
+		}
+	})
+
+	t.Run("default_wordlist", func(t *testing.T) {
+		t.Parallel()
+		pass, err := NewPassphraseGenerator().Generate(4)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		words := strings.Split(pass, DefaultPassphraseSeparator)
+		if len(words) != 4 {
+			t.Fatalf("expected: 4 words, actual: %d in %s", len(words), pass)
+		}
+		for _, word := range words {
+			if !slices.Contains(DefaultWordlist, word) {
+				t.Errorf("word %q is not part of the default wordlist", word)
+			}
+		}
+	})
+
+	t.Run("capitalize", func(t *testing.T) {
+		t.Parallel()
+		gen := NewPassphraseGenerator().WithWordlist([]string{"word", "éclair"}).Capitalize(CapitalizeTitle)
+		for i := 0; i < 10; i++ {
+			pass, err := gen.Generate(3)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			for _, word := range strings.Split(pass, DefaultPassphraseSeparator) {
+				if word != "Word" && word != "Éclair" {
+					t.Errorf("expected word %q of %s to be capitalized", word, pass)
+				}
+			}
+		}
+	})
+
+	t.Run("capitalize_first", func(t *testing.T) {
+		t.Parallel()
+		pass, err := NewPassphraseGenerator().WithWordlist([]string{"word"}).Capitalize(CapitalizeFirst).Generate(3)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if pass != "Word-word-word" {
+			t.Errorf("expected: %q, actual: %q", "Word-word-word", pass)
+		}
+	})
+
+	t.Run("capitalize_random", func(t *testing.T) {
+		t.Parallel()
+		gen := NewPassphraseGenerator().WithWordlist([]string{"word"}).Capitalize(CapitalizeRandom)
+		seen := map[string]bool{}
+		for i := 0; i < 20; i++ {
+			pass, err := gen.Generate(8)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			for _, word := range strings.Split(pass, DefaultPassphraseSeparator) {
+				if word != "word" && word != "Word" {
+					t.Fatalf("expected word %q of %s to be word or Word", word, pass)
+				}
+				seen[word] = true
+			}
+		}
+		if !seen["word"] || !seen["Word"] {
+			t.Errorf("expected both capitalizations, received %v", seen)
+		}
+	})
+
+	t.Run("padding", func(t *testing.T) {
+		t.Parallel()
+		gen := NewPassphraseGenerator().WithWordlist([]string{"word"}).WithSeparatorSymbols("!").WithPadding(2, 1)
+		for i := 0; i < 10; i++ {
+			pass, err := gen.Generate(2)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			parts := strings.Split(pass, DefaultPassphraseSeparator)
+			if len(parts) != 3 || parts[0] != "word" || parts[1] != "word" {
+				t.Fatalf("expected two words and the padding, received %q", pass)
+			}
+			pad := parts[2]
+			if len(pad) != 3 || !strings.ContainsRune(Digits, rune(pad[0])) || !strings.ContainsRune(Digits, rune(pad[1])) || pad[2] != '!' {
+				t.Errorf("expected two digits followed by !, received %q", pad)
+			}
+		}
+	})
+
+	t.Run("padding_symbols_only", func(t *testing.T) {
+		t.Parallel()
+		pass, err := NewPassphraseGenerator().WithWordlist([]string{"word"}).WithSeparator("").
+			WithSeparatorSymbols("#").WithPadding(0, 2).Generate(2)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if pass != "wordword##" {
+			t.Errorf("expected: %q, actual: %q", "wordword##", pass)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+		if _, err := NewPassphraseGenerator().WithWordlist(nil).Generate(4); !errors.Is(err, ErrEmptyWordlist) {
+			t.Errorf("expected: %q, actual: %q", ErrEmptyWordlist, err)
+		}
+		if _, err := NewPassphraseGenerator().WithWordlist(testWords).Generate(0); !errors.Is(err, ErrInvalidWordCount) {
+			t.Errorf("expected: %q, actual: %q", ErrInvalidWordCount, err)
+		}
+		gen := NewPassphraseGenerator().WithWordlist(testWords).WithSymbolSeparator().WithSeparatorSymbols("")
+		if _, err := gen.Generate(4); !errors.Is(err, ErrEmptyCharacterSet) {
+			t.Errorf("expected: %q, actual: %q", ErrEmptyCharacterSet, err)
+		}
+		gen = NewPassphraseGenerator().WithWordlist(testWords).WithPadding(1, 1).WithSeparatorSymbols("")
+		if _, err := gen.Generate(4); !errors.Is(err, ErrEmptyCharacterSet) {
+			t.Errorf("expected: %q, actual: %q", ErrEmptyCharacterSet, err)
+		}
+		if _, err := NewPassphraseGenerator().WithWordlist(testWords).WithPadding(-1, 0).Generate(4); !errors.Is(err, ErrInvalidPadding) {
+			t.Errorf("expected: %q, actual: %q", ErrInvalidPadding, err)
+		}
+	})
+}
+
+func TestGenerateWords(t *testing.T) {
+	t.Parallel()
+
+	t.Run("words", func(t *testing.T) {
+		t.Parallel()
+		code, err := GenerateWords(3, testWords, ".")
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		words := strings.Split(code, ".")
+		if len(words) != 3 {
+			t.Errorf("expected: 3 words, actual: %d in %s", len(words), code)
+		}
+		for _, word := range words {
+			if !slices.Contains(testWords, word) {
+				t.Errorf("word %s of %s is not in the wordlist", word, code)
+			}
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+		if _, err := GenerateWords(3, nil, "."); !errors.Is(err, ErrEmptyWordlist) {
+			t.Errorf("expected: %q, actual: %q", ErrEmptyWordlist, err)
+		}
+		if _, err := GenerateWords(0, testWords, "."); !errors.Is(err, ErrInvalidWordCount) {
+			t.Errorf("expected: %q, actual: %q", ErrInvalidWordCount, err)
+		}
+	})
+}
@@ -13,8 +13,14 @@ package passwordgen
 import (
 	"crypto/rand"
 	"errors"
+	"fmt"
+	"io"
+	"math"
 	"math/big"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 )
 
 const (
@@ -41,25 +47,77 @@ const (
 
 	// SymbolsNoAmbig is the list of symbols.
 	SymbolsNoAmbig = "~!@#$%^&*()_+-={}[]"
+
+	// ShellSafeSymbols is the list of symbols which can be passed as a command line
+	// argument to common shells (sh, bash, zsh) without quoting or escaping.
+	ShellSafeSymbols = "%+,-./:@_"
 )
 
+// errValidationFailed is reported by GenerateSatisfying for a password the validator rejected.
+var errValidationFailed = errors.New("password rejected by validator")
+
+// DefaultMaxAttempts is the number of times a password is regenerated while
+// trying to satisfy a constraint before giving up, unless set with WithMaxAttempts.
+const DefaultMaxAttempts = 100
+
+// DefaultMaxLength is the longest password a generator will generate, unless set with
+// WithMaxLength.
+const DefaultMaxLength = 4096
+
+// DefaultLength is the length of the passwords generated by GenerateDefault, unless set with
+// WithLength.
+const DefaultLength = 16
+
 var (
 	// ErrExceedsTotalLength is the error returned when the number of required
-	// elements is greater then the length of the requestd password
+	// elements is greater then the length of the requestd password, it is wrapped
+	// in a *LengthError carrying the lengths
 	ErrExceedsTotalLength = errors.New("the number of required elements exceeds requested password length")
 
 	// ErrNoCharactersSpecified is the error returned when a generator is called
-	// without any characters specified
+	// without any characters specified, it is wrapped in a *NoCharactersError
+	// carrying the positions which couldn't be filled
 	ErrNoCharactersSpecified = errors.New("no characters specified in generator")
+
+	// ErrInvalidLengthDistribution is the error returned when a length
+	// distribution is empty, contains a non-positive length or weight, or its
+	// weights add up to more than the largest int
+	ErrInvalidLengthDistribution = errors.New("length distribution must contain positive lengths with positive weights")
+
+	// ErrInsufficientClasses is the error returned when more distinct character
+	// classes are required than are enabled in the generator
+	ErrInsufficientClasses = errors.New("required class diversity exceeds the number of enabled character classes")
+
+	// ErrInvalidGrouping is the error returned when a grouped password is
+	// requested with a non-positive group size or group count
+	ErrInvalidGrouping = errors.New("group size and group count must be positive")
+
+	// ErrMaxAttemptsExceeded is the error returned when no generated password
+	// satisfied the constraints within the maximum number of attempts
+	ErrMaxAttemptsExceeded = errors.New("maximum number of generation attempts exceeded")
+
+	// ErrEmptyCharacterSet is the error returned when a class is enabled in a
+	// generator but its set of characters is empty
+	ErrEmptyCharacterSet = errors.New("character set of an enabled class is empty")
+
+	// ErrExceedsMaxLength is the error returned when the requested password
+	// length is greater than the maximum length of the generator
+	ErrExceedsMaxLength = errors.New("requested password length exceeds the maximum length")
 )
 
 // Generator is the stateful generator which can be used to customize the list
 // of letters, digits, and/or symbols.
+//
+// A configured generator is safe for concurrent use, Generate and the other methods which
+// generate passwords only read the configuration.  The methods which change the configuration
+// must not be called while the generator is in use by other goroutines, configure a Clone or
+// use a GeneratorPool instead.
 type Generator struct {
 	lowerLetters string
 	upperLetters string
 	digits       string
 	symbols      string
+	excluded     string
 
 	withLower   bool
 	withUpper   bool
@@ -70,6 +128,68 @@ type Generator struct {
 	requireUpper   int
 	requireDigits  int
 	requireSymbols int
+
+	exactLower   bool
+	exactUpper   bool
+	exactDigits  bool
+	exactSymbols bool
+
+	classDiversity int
+
+	requiredSets []setRequirement
+	charsets     []charset
+
+	englishFrequency bool
+
+	classWeights    [4]int
+	hasClassWeights bool
+
+	classMax    [4]int
+	hasClassMax [4]bool
+
+	balancedClasses bool
+
+	maxAttempts int
+
+	maxLength int
+
+	length int
+
+	autoLength bool
+
+	minEntropy float64
+
+	emojis []string
+
+	forbidKeyboardSequences bool
+	maxSequence             int
+
+	maxRepeat int
+
+	forbidLeadingDigit    bool
+	forbidBoundarySymbols bool
+	startWithLetter       bool
+	pins                  []pin
+
+	uniquePerClass bool
+
+	noCrossClassConfusables bool
+
+	maxSymbolFraction float64
+	hasSymbolFraction bool
+
+	caseRatio    float64
+	hasCaseRatio bool
+
+	breachChecker BreachChecker
+
+	cache *poolCache
+
+	random io.Reader
+
+	rotatingClasses []CharClass
+	rotationPeriod  time.Duration
+	now             func() time.Time
 }
 
 // NewGenerator Returns a new empty generator.
@@ -79,43 +199,147 @@ func NewGenerator() *Generator {
 		upperLetters: UpperLetters,
 		digits:       Digits,
 		symbols:      Symbols,
+		cache:        &poolCache{},
 	}
 }
 
+// Clone returns a copy of the generator which can be configured independently, e.g. a base
+// generator tweaked per goroutine.  The configuration is deep-copied, changing the clone never
+// affects the original or other clones, and the clone may be configured while the original
+// generates passwords.
+func (g *Generator) Clone() *Generator {
+	clone := *g
+	clone.rotatingClasses = append([]CharClass(nil), g.rotatingClasses...)
+	clone.pins = append([]pin(nil), g.pins...)
+	clone.emojis = append([]string(nil), g.emojis...)
+	clone.requiredSets = append([]setRequirement(nil), g.requiredSets...)
+	clone.charsets = append([]charset(nil), g.charsets...)
+	return &clone
+}
+
 // NoAmbiguousCharacters ensures no ambiguous characters will be in the password.
 func (g *Generator) NoAmbiguousCharacters() *Generator {
+	return g.NoAmbiguousLetters().NoAmbiguousDigits().NoAmbiguousSymbols()
+}
+
+// NoAmbiguousLetters ensures no ambiguous lower or upper case letters will be in the password.
+func (g *Generator) NoAmbiguousLetters() *Generator {
 	g.lowerLetters = LowerLettersNoAmbig
 	g.upperLetters = UpperLettersNoAmbig
+	g.invalidate()
+	return g
+}
+
+// NoAmbiguousDigits ensures no ambiguous digits will be in the password.
+func (g *Generator) NoAmbiguousDigits() *Generator {
 	g.digits = DigitsNoAmbig
+	g.invalidate()
+	return g
+}
+
+// NoAmbiguousSymbols ensures no ambiguous symbols will be in the password.
+func (g *Generator) NoAmbiguousSymbols() *Generator {
 	g.symbols = SymbolsNoAmbig
+	g.invalidate()
+	return g
+}
+
+// WithShellSafeSymbols restricts the symbols to ShellSafeSymbols, excluding symbols such as
+// `, $, \, ", ', !, *, and & which shells would expand or interpret.  This is a best-effort
+// heuristic for common shells, not a guarantee for every shell or context.
+func (g *Generator) WithShellSafeSymbols() *Generator {
+	g.symbols = ShellSafeSymbols
+	g.invalidate()
 	return g
 }
 
+// WithEmojiSet adds the given emojis to the symbols and adds symbols to the password pool.  Each
+// emoji is a single element of the password even when it is made of several runes, e.g. a
+// flag or an emoji with a skin tone modifier, so lengths and required counts are measured in
+// emojis rather than runes.
+func (g *Generator) WithEmojiSet(emojis []string) *Generator {
+	g.emojis = append([]string(nil), emojis...)
+	return g.WithSymbols()
+}
+
+// WithCustomSymbols replaces the symbols with the given characters, e.g. "!@#$" for a system
+// which rejects brackets.  Required and exact symbols are drawn from the custom symbols too.
+// Generate returns ErrEmptyCharacterSet if chars is empty and symbols are enabled or required.
+func (g *Generator) WithCustomSymbols(chars string) *Generator {
+	g.symbols = chars
+	g.invalidate()
+	return g
+}
+
+// ExcludeCharacters removes the given characters from every pool, including custom symbols,
+// charsets, and the sets of RequireFromSet, e.g. ExcludeCharacters(`"'\`) for a system which
+// rejects quotes and backslashes.  Characters of later calls are excluded as well.  Generate
+// returns ErrEmptyCharacterSet if a class in use has no characters left.
+func (g *Generator) ExcludeCharacters(chars string) *Generator {
+	g.excluded += chars
+	g.invalidate()
+	return g
+}
+
+// withoutExcluded returns the elements which aren't excluded by ExcludeCharacters.
+func (g *Generator) withoutExcluded(elements []string) []string {
+	if g.excluded == "" {
+		return elements
+	}
+	return slices.DeleteFunc(slices.Clone(elements), func(elm string) bool {
+		return strings.Contains(g.excluded, elm)
+	})
+}
+
+// WithLocaleDigits replaces the digits with the given locale specific digits, e.g. the
+// Arabic-Indic digits "٠١٢٣٤٥٦٧٨٩", and adds digits to the password pool.  Generate returns
+// ErrEmptyCharacterSet if digits is empty.
+func (g *Generator) WithLocaleDigits(digits string) *Generator {
+	g.digits = digits
+	return g.WithDigits()
+}
+
 // WithLower adds lower case letters to the password pool.
 // Does not guarantee lower case letters will be present in the generated password.
+// Has no effect once ExactLower is set, see ExactLower.
 func (g *Generator) WithLower() *Generator {
-	g.withLower = true
+	if !g.exactLower {
+		g.withLower = true
+	}
+	g.invalidate()
 	return g
 }
 
 // WithUpper adds upper case letters to the password pool.
 // Does not guarantee upper case letters will be present in the generated password.
+// Has no effect once ExactUpper is set, see ExactUpper.
 func (g *Generator) WithUpper() *Generator {
-	g.withUpper = true
+	if !g.exactUpper {
+		g.withUpper = true
+	}
+	g.invalidate()
 	return g
 }
 
 // WithDigits adds digits to the password pool.
 // Does not guarantee digits will be present in the generated password.
+// Has no effect once ExactDigits is set, see ExactDigits.
 func (g *Generator) WithDigits() *Generator {
-	g.withDigits = true
+	if !g.exactDigits {
+		g.withDigits = true
+	}
+	g.invalidate()
 	return g
 }
 
 // WithSymbols adds symbols to the password pool.
 // Does not guarantee symbols will be present in the generated password.
+// Has no effect once ExactSymbols is set, see ExactSymbols.
 func (g *Generator) WithSymbols() *Generator {
-	g.withSymbols = true
+	if !g.exactSymbols {
+		g.withSymbols = true
+	}
+	g.invalidate()
 	return g
 }
 
@@ -123,6 +347,8 @@ func (g *Generator) WithSymbols() *Generator {
 func (g *Generator) RequireLower(N int) *Generator {
 	g.withLower = true
 	g.requireLower = N
+	g.exactLower = false
+	g.invalidate()
 	return g
 }
 
@@ -130,6 +356,8 @@ func (g *Generator) RequireLower(N int) *Generator {
 func (g *Generator) RequireUpper(N int) *Generator {
 	g.withUpper = true
 	g.requireUpper = N
+	g.exactUpper = false
+	g.invalidate()
 	return g
 }
 
@@ -137,6 +365,8 @@ func (g *Generator) RequireUpper(N int) *Generator {
 func (g *Generator) RequireDigits(N int) *Generator {
 	g.withDigits = true
 	g.requireDigits = N
+	g.exactDigits = false
+	g.invalidate()
 	return g
 }
 
@@ -144,148 +374,758 @@ func (g *Generator) RequireDigits(N int) *Generator {
 func (g *Generator) RequireSymbols(N int) *Generator {
 	g.withSymbols = true
 	g.requireSymbols = N
+	g.exactSymbols = false
+	g.invalidate()
 	return g
 }
 
 // ExactLower guarantees that there are exactly N lower case letters in the generated password.
+// It takes precedence over WithLower regardless of the order they are called in, a later call
+// to RequireLower replaces it.
 func (g *Generator) ExactLower(N int) *Generator {
 	g.withLower = false
 	g.requireLower = N
+	g.exactLower = true
+	g.invalidate()
 	return g
 }
 
 // ExactUpper guarantees that there are exactly N upper case letters in the generated password.
+// It takes precedence over WithUpper regardless of the order they are called in, a later call
+// to RequireUpper replaces it.
 func (g *Generator) ExactUpper(N int) *Generator {
 	g.withUpper = false
 	g.requireUpper = N
+	g.exactUpper = true
+	g.invalidate()
 	return g
 }
 
 // ExactDigits guarantees that there are exactly N digits in the generated password.
+// It takes precedence over WithDigits regardless of the order they are called in, a later call
+// to RequireDigits replaces it.
 func (g *Generator) ExactDigits(N int) *Generator {
 	g.withDigits = false
 	g.requireDigits = N
+	g.exactDigits = true
+	g.invalidate()
 	return g
 }
 
 // ExactSymbols guarantees that there are exactly N symbols in the generated password.
+// It takes precedence over WithSymbols regardless of the order they are called in, a later call
+// to RequireSymbols replaces it.
 func (g *Generator) ExactSymbols(N int) *Generator {
 	g.withSymbols = false
 	g.requireSymbols = N
+	g.exactSymbols = true
+	g.invalidate()
+	return g
+}
+
+// RequireClassDiversity guarantees that characters from at least N distinct enabled classes
+// will be in the generated password.  Classes with required characters count towards N, the
+// remaining classes are picked at random from the enabled classes.
+func (g *Generator) RequireClassDiversity(N int) *Generator {
+	g.classDiversity = N
+	return g
+}
+
+// RequireFromSet guarantees that at least N characters drawn from set will be in the generated
+// password, set can be any characters and doesn't have to be part of an enabled class.  Each
+// call adds a separate requirement, the drawn characters aren't counted towards the
+// requirements of the classes they belong to.  A set which contains characters of a class set
+// with an Exact method would break the exact count, Generate returns ErrInvalidConfig for it.
+func (g *Generator) RequireFromSet(set string, N int) *Generator {
+	g.requiredSets = append(g.requiredSets, setRequirement{elements: split(set), n: N})
+	return g
+}
+
+// setRequirement is a set of characters added by RequireFromSet along with how many of them
+// must be present.
+type setRequirement struct {
+	elements []string
+	n        int
+}
+
+// requiredFromSets returns the number of positions reserved by RequireFromSet.
+func (g *Generator) requiredFromSets() int {
+	total := 0
+	for _, set := range g.setRequirements() {
+		total += max(set.n, 0)
+	}
+	return total
+}
+
+// WithTimeSeededClassRequirement requires at least one character from one of the given classes,
+// rotating through the classes each period so passwords generated over time vary in
+// composition.  The required class is determined by the current time, every password
+// generated within the same period requires the same class.
+func (g *Generator) WithTimeSeededClassRequirement(classes []CharClass, period time.Duration) *Generator {
+	g.rotatingClasses = append([]CharClass(nil), classes...)
+	g.rotationPeriod = period
+	return g
+}
+
+// WithClassWeights sets the rough proportion of each class among the characters which fill the
+// password after the required characters are placed, e.g. WithClassWeights(3, 3, 3, 1) draws a
+// symbol for roughly one in ten of the free positions.  The weights only apply to enabled
+// classes and are approximate, a class with a weight of 0 is not used to fill the password.
+// Generate returns ErrInvalidConfig for a negative weight or one above MaxClassWeight.
+func (g *Generator) WithClassWeights(lower, upper, digits, symbols int) *Generator {
+	g.classWeights = [4]int{lower, upper, digits, symbols}
+	g.hasClassWeights = true
+	g.invalidate()
+	return g
+}
+
+// BalancedClasses makes each enabled class fill roughly the same share of the free positions,
+// regardless of the size of its pool.  A class is picked uniformly first, then a character
+// within it, so the 10 digits are drawn as often as the 26 lower case letters.  Required
+// characters are still placed first, and WithClassWeights takes precedence.
+func (g *Generator) BalancedClasses() *Generator {
+	g.balancedClasses = true
+	g.invalidate()
+	return g
+}
+
+// WithMaxAttempts sets how many times a password is regenerated while trying to satisfy a
+// constraint before ErrMaxAttemptsExceeded is returned.  Values less than 1 restore
+// DefaultMaxAttempts.
+func (g *Generator) WithMaxAttempts(n int) *Generator {
+	g.maxAttempts = n
+	return g
+}
+
+// WithAutoLength makes Generate grow lengths which are too short for the requirements to the
+// minimum length which fits them, see MinLength, instead of returning ErrExceedsTotalLength.
+// The grown length is still subject to WithMaxLength, requirements which don't fit in the
+// maximum length return ErrExceedsMaxLength.  GenerateGrouped isn't affected since growing
+// the length would break the grouping.
+func (g *Generator) WithAutoLength() *Generator {
+	g.autoLength = true
+	return g
+}
+
+// WithRand sets the source of randomness every character and the order of the characters are
+// drawn from, in place of crypto/rand.  A deterministic reader makes the generated passwords
+// reproducible, which is useful in tests but unsafe for real passwords.  A nil reader restores
+// crypto/rand.
+func (g *Generator) WithRand(r io.Reader) *Generator {
+	g.random = r
+	return g
+}
+
+// WithMaxLength sets the longest password Generate will generate, longer lengths return
+// ErrExceedsMaxLength.  This protects services which pass untrusted lengths through from
+// allocating huge passwords.  Values less than 1 restore DefaultMaxLength.
+func (g *Generator) WithMaxLength(max int) *Generator {
+	g.maxLength = max
+	return g
+}
+
+// WithLength sets the length of the passwords generated by GenerateDefault, so a generator can
+// carry the length of its policy.  Values less than 1 restore DefaultLength.
+func (g *Generator) WithLength(length int) *Generator {
+	g.length = length
+	return g
+}
+
+// GenerateDefault will generate a password at the length set with WithLength as configured.
+func (g *Generator) GenerateDefault() (string, error) {
+	length := g.length
+	if length < 1 {
+		length = DefaultLength
+	}
+	return g.Generate(length)
+}
+
+// ForbidKeyboardSequences regenerates any password which contains a walk of 3 or more adjacent
+// keys on a US QWERTY keyboard, such as qwe, asdf, or 1qaz, up to the maximum number of
+// attempts.  Random passwords rarely contain such walks, this guarantees it for scanners which
+// reject them.
+func (g *Generator) ForbidKeyboardSequences() *Generator {
+	g.forbidKeyboardSequences = true
+	return g
+}
+
+// NoSequences regenerates any password which contains an ascending or descending run of more
+// than maxRun consecutive characters, such as abcd or 4321, or a walk of more than maxRun
+// adjacent keys on a US QWERTY keyboard, such as qwerty or asdf, up to the maximum number of
+// attempts.  For example NoSequences(2) allows ab but not abc.  Values of maxRun less than 1
+// allow any sequence.
+func (g *Generator) NoSequences(maxRun int) *Generator {
+	g.maxSequence = max(maxRun, 0)
 	return g
 }
 
-// Generate will generate a password at the specified length as configured.
+// MinLength returns the smallest length which can be passed to Generate without returning
+// ErrExceedsTotalLength.
+func (g *Generator) MinLength() int {
+	// An invalid diversity is reported by Generate, it doesn't change the minimum length.
+	_, missing, _ := g.diversityCandidates()
+	requireLower, requireUpper, requireDigits, requireSymbols := g.requiredCounts()
+	return requireLower + requireUpper + requireDigits + requireSymbols + g.requiredFromSets() + missing
+}
+
+// Generate will generate a password at the specified length as configured.  The length is a
+// number of characters rather than bytes, so passwords drawn from multibyte pools are longer
+// than length in bytes.
 func (g *Generator) Generate(length int) (string, error) {
-	if !g.withLower && !g.withUpper && !g.withDigits && !g.withSymbols {
-		return "", ErrNoCharactersSpecified
+	elements, err := g.generate(length)
+	if err != nil {
+		return "", err
 	}
+	return strings.Join(elements, ""), nil
+}
+
+// generate generates the elements of a password at the specified length as configured.  Each
+// element is a single character, or a single emoji added with WithEmojiSet.
+func (g *Generator) generate(length int) ([]string, error) {
+	elements, _, err := g.generatePlanned(length)
+	return elements, err
+}
 
-	buffer := strings.Builder{}
+// generatePlanned generates the elements of a password like generate, along with the plan
+// they were drawn by.
+func (g *Generator) generatePlanned(length int) ([]string, *GenerationPlan, error) {
+	plan, err := g.Plan(length)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !g.hasRetryConstraints() {
+		elements, err := g.build(plan)
+		return elements, plan, err
+	}
 
-	if g.requireLower+g.requireUpper+g.requireDigits+g.requireSymbols > length {
-		return "", ErrExceedsTotalLength
+	for i := 0; i < g.attempts(); i++ {
+		elements, err := g.build(plan)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !g.accepts(elements) {
+			continue
+		}
+		breached, err := g.breached(elements)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !breached {
+			return elements, plan, nil
+		}
 	}
+	return nil, nil, ErrMaxAttemptsExceeded
+}
 
-	if g.requireLower > 0 {
-		for i := 0; i < g.requireLower; i++ {
-			elm, err := randomElement(LowerLetters)
+// build draws the elements of a password as allocated by the plan.
+func (g *Generator) build(plan *GenerationPlan) ([]string, error) {
+	requireLower, requireUpper, requireDigits, requireSymbols := plan.Lower, plan.Upper, plan.Digits, plan.Symbols
+	candidates, missing := plan.candidates, plan.Diversity
+
+	buffer := make([]string, 0, plan.Length)
+	picker := g.newPicker()
+	pools := g.pools()
+
+	// Required characters are drawn from the configured pools, so they honor custom
+	// characters, NoAmbiguousCharacters, and ExcludeCharacters like the rest of the password.
+	if requireLower > 0 {
+		for i := 0; i < requireLower; i++ {
+			elm, err := picker.draw(pools.elements[ClassLower], g.letterElement)
 			if err != nil {
-				return "", err
+				return nil, err
 			}
-			buffer.WriteString(elm)
+			buffer = append(buffer, elm)
 		}
 	}
 
-	if g.requireUpper > 0 {
-		for i := 0; i < g.requireUpper; i++ {
-			elm, err := randomElement(UpperLetters)
+	if requireUpper > 0 {
+		for i := 0; i < requireUpper; i++ {
+			elm, err := picker.draw(pools.elements[ClassUpper], g.letterElement)
 			if err != nil {
-				return "", err
+				return nil, err
 			}
-			buffer.WriteString(elm)
+			buffer = append(buffer, elm)
 		}
 	}
 
-	if g.requireDigits > 0 {
-		for i := 0; i < g.requireDigits; i++ {
-			elm, err := randomElement(Digits)
+	if requireDigits > 0 {
+		for i := 0; i < requireDigits; i++ {
+			elm, err := picker.draw(pools.elements[ClassDigit], g.randomElement)
 			if err != nil {
-				return "", err
+				return nil, err
 			}
-			buffer.WriteString(elm)
+			buffer = append(buffer, elm)
 		}
 	}
 
-	if g.requireSymbols > 0 {
-		for i := 0; i < g.requireSymbols; i++ {
-			elm, err := randomElement(Symbols)
+	if requireSymbols > 0 {
+		for i := 0; i < requireSymbols; i++ {
+			elm, err := picker.draw(pools.elements[ClassSymbol], g.randomElement)
 			if err != nil {
-				return "", err
+				return nil, err
 			}
-			buffer.WriteString(elm)
+			buffer = append(buffer, elm)
 		}
 	}
 
-	bufferLen := buffer.Len()
-	if bufferLen < length {
-		// Need to continue building the password pool
-		valuesBuilder := strings.Builder{}
-		if g.withLower {
-			valuesBuilder.WriteString(g.lowerLetters)
-		}
-		if g.withUpper {
-			valuesBuilder.WriteString(g.upperLetters)
+	for _, set := range g.setRequirements() {
+		for i := 0; i < set.n; i++ {
+			elm, err := picker.draw(set.elements, g.randomElement)
+			if err != nil {
+				return nil, err
+			}
+			buffer = append(buffer, elm)
 		}
-		if g.withDigits {
-			valuesBuilder.WriteString(g.digits)
+	}
+
+	// Draw one character from randomly picked classes until enough classes are present.
+	for i := 0; i < missing; i++ {
+		n, err := rand.Int(g.reader(), big.NewInt(int64(len(candidates))))
+		if err != nil {
+			return nil, err
 		}
-		if g.withSymbols {
-			valuesBuilder.WriteString(g.symbols)
+		idx := n.Int64()
+		elm, err := picker.draw(candidates[idx], g.randomElement)
+		if err != nil {
+			return nil, err
 		}
-		// The only reason this could be zero is Exact<type> was used and we don't have enough
-		// characters in the password buffer.  Error out as an invalid password generator
-		// was created.
-		if valuesBuilder.Len() == 0 {
-			return "", ErrNoCharactersSpecified
+		buffer = append(buffer, elm)
+		candidates = append(candidates[:idx], candidates[idx+1:]...)
+	}
+
+	if plan.Free > 0 {
+		// Classes which reach their limit are left out of the rest of the fill.
+		limited := slices.Contains(plan.capped[:], true)
+		var counts [4]int
+		if limited {
+			counts = g.countElements(buffer)
 		}
-		values := valuesBuilder.String()
+		capped := map[[4]bool]*fillPools{}
 		// Fill the password pool up to the defined length
-		for i := 0; i < length-bufferLen; i++ {
-			elm, err := randomElement(values)
+		for i := 0; i < plan.Free; i++ {
+			fill, elements, weighted := plan.fill, pools.elements, pools.weighted
+			if full := plan.full(counts); limited && full != [4]bool{} {
+				if capped[full] == nil {
+					capped[full] = g.cappedPools(full)
+				}
+				fill, elements, weighted = capped[full].fill, capped[full].elements, capped[full].weighted
+			}
+
+			var elm string
+			var err error
+			if g.weightedFill() {
+				elm, err = picker.drawWeighted(g, elements, weighted)
+			} else {
+				elm, err = picker.draw(fill, g.randomElement)
+			}
 			if err != nil {
-				return "", err
+				return nil, err
+			}
+			if limited {
+				if class, ok := g.elementClass(elm); ok {
+					counts[class]++
+				}
+			}
+			buffer = append(buffer, elm)
+		}
+	}
+
+	// We now have a buffer with the passwords elements, shuffle them so the required
+	// elements aren't at the front.
+	if err := shuffle(g.reader(), buffer); err != nil {
+		return nil, err
+	}
+	if err := g.arrange(buffer); err != nil {
+		return nil, err
+	}
+
+	return buffer, nil
+}
+
+// UnusedPoolCharacters returns the characters of the generator's pool which do not appear in
+// the given password, in pool order.  Useful for auditing how much of the pool is covered
+// over many generated passwords.
+func (g *Generator) UnusedPoolCharacters(password string) string {
+	unused := strings.Builder{}
+	for _, elm := range g.effectivePool() {
+		if !strings.Contains(password, elm) {
+			unused.WriteString(elm)
+		}
+	}
+	return unused.String()
+}
+
+// PoolSize returns the number of distinct characters the generator may place in a password,
+// after enabled classes, NoAmbiguousCharacters, custom sets and exclusions are applied.  This
+// is the pool size behind the generator's entropy estimates, 0 if no classes are enabled.
+func (g *Generator) PoolSize() int {
+	return len(g.effectivePool())
+}
+
+// GenerateValidated will generate a password at the specified length which
+// passes the given validator. Passwords are regenerated until v returns nil, if
+// no password passes within the maximum number of attempts an error wrapping both
+// ErrMaxAttemptsExceeded and the last error returned by v is returned.
+func (g *Generator) GenerateValidated(length int, v func(string) error) (string, error) {
+	var lastErr error
+	for i := 0; i < g.attempts(); i++ {
+		pass, err := g.Generate(length)
+		if err != nil {
+			return "", err
+		}
+		if lastErr = v(pass); lastErr == nil {
+			return pass, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %w", ErrMaxAttemptsExceeded, lastErr)
+}
+
+// GenerateGrouped will generate a password of groupSize*groups characters as configured and
+// join the groups with sep, e.g. X4K2-9QMW-7RT3.  The separators do not count towards the
+// configured requirements.
+func (g *Generator) GenerateGrouped(groupSize, groups int, sep string) (string, error) {
+	if groupSize <= 0 || groups <= 0 {
+		return "", ErrInvalidGrouping
+	}
+	// Checked before multiplying so huge groupings can't overflow past the limit.
+	if groupSize > g.maxPasswordLength()/groups {
+		return "", ErrExceedsMaxLength
+	}
+
+	if required := g.MinLength(); required > groupSize*groups {
+		return "", &LengthError{Required: required, Requested: groupSize * groups}
+	}
+
+	elements, err := g.generate(groupSize * groups)
+	if err != nil {
+		return "", err
+	}
+
+	parts := make([]string, groups)
+	for i := range parts {
+		parts[i] = strings.Join(elements[i*groupSize:(i+1)*groupSize], "")
+	}
+	return strings.Join(parts, sep), nil
+}
+
+// GenerateSatisfying will generate a password at the specified length for which validate
+// returns true, regenerating up to the maximum number of attempts.  It is a convenience over
+// GenerateValidated for validators which report a bool, a validator which rarely or never
+// returns true exhausts the attempts and ErrMaxAttemptsExceeded is returned.
+func (g *Generator) GenerateSatisfying(length int, validate func(string) bool) (string, error) {
+	pass, err := g.GenerateValidated(length, func(pass string) error {
+		if !validate(pass) {
+			return errValidationFailed
+		}
+		return nil
+	})
+	if errors.Is(err, ErrMaxAttemptsExceeded) {
+		return "", ErrMaxAttemptsExceeded
+	}
+	return pass, err
+}
+
+// GenerateAvoiding will generate a password at the specified length which contains none of
+// the forbidden substrings, compared case insensitively, e.g. a user's name or the local part
+// of their email.  Passwords containing any of them are regenerated up to the maximum number
+// of attempts set with WithMaxAttempts, after which ErrMaxAttemptsExceeded is returned.
+func (g *Generator) GenerateAvoiding(length int, forbidden []string) (string, error) {
+	var lowered []string
+	for _, s := range forbidden {
+		// An empty substring is contained in every password.
+		if s != "" {
+			lowered = append(lowered, strings.ToLower(s))
+		}
+	}
+	return g.GenerateSatisfying(length, func(pass string) bool {
+		pass = strings.ToLower(pass)
+		for _, s := range lowered {
+			if strings.Contains(pass, s) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// GenerateFromLengthDistribution will generate a password whose length is chosen
+// from the weighted distribution of lengths given, e.g. map[int]int{16: 9, 20: 1}
+// produces a 16 character password nine times out of ten and a 20 character
+// password otherwise.
+func (g *Generator) GenerateFromLengthDistribution(weights map[int]int) (string, error) {
+	if len(weights) == 0 {
+		return "", ErrInvalidLengthDistribution
+	}
+
+	required := g.MinLength()
+	lengths := make([]int, 0, len(weights))
+	total := 0
+	for length, weight := range weights {
+		if length <= 0 || weight <= 0 || weight > math.MaxInt-total {
+			return "", ErrInvalidLengthDistribution
+		}
+		if required > length && !g.autoLength {
+			return "", &LengthError{Required: required, Requested: length}
+		}
+		lengths = append(lengths, length)
+		total += weight
+	}
+	// Map iteration order is random, sort so the same draw always maps to the same length.
+	sort.Ints(lengths)
+
+	n, err := rand.Int(g.reader(), big.NewInt(int64(total)))
+	if err != nil {
+		return "", err
+	}
+	pick := int(n.Int64())
+	for _, length := range lengths {
+		pick -= weights[length]
+		if pick < 0 {
+			return g.Generate(length)
+		}
+	}
+	// Unreachable, the draw is always less than the total weight.
+	return g.Generate(lengths[len(lengths)-1])
+}
+
+// hasRetryConstraints returns whether a generated password must be checked with accepts or
+// the BreachChecker.
+func (g *Generator) hasRetryConstraints() bool {
+	return g.forbidKeyboardSequences || g.maxSequence > 0 || g.maxRepeat > 0 || g.hasPositionalConstraints() ||
+		g.breachChecker != nil || slices.Contains(g.hasClassMax[:], true)
+}
+
+// accepts returns whether the generated elements satisfy the constraints which are enforced by
+// regenerating the password.
+func (g *Generator) accepts(elements []string) bool {
+	if g.forbidKeyboardSequences && keyboardWalk(elements) >= minKeyboardWalk {
+		return false
+	}
+	if g.maxSequence > 0 && (sequenceRun(elements) > g.maxSequence || keyboardWalk(elements) > g.maxSequence) {
+		return false
+	}
+	if g.maxRepeat > 0 && longestRun(elements) > g.maxRepeat {
+		return false
+	}
+	if !g.withinClassMax(elements) {
+		return false
+	}
+	return g.validPositions(elements)
+}
+
+// maxPasswordLength returns the longest password the generator will generate.
+func (g *Generator) maxPasswordLength() int {
+	if g.maxLength < 1 {
+		return DefaultMaxLength
+	}
+	return g.maxLength
+}
+
+// attempts returns the maximum number of attempts to satisfy a constraint.
+func (g *Generator) attempts() int {
+	if g.maxAttempts < 1 {
+		return DefaultMaxAttempts
+	}
+	return g.maxAttempts
+}
+
+// requiredCounts returns how many characters of each class must be in the password, including
+// the class required by WithTimeSeededClassRequirement and the positions pinned by PinPosition.
+func (g *Generator) requiredCounts() (lower, upper, digits, symbols int) {
+	pinned := g.pinnedCounts()
+	lower = max(g.requireLower, pinned[ClassLower])
+	upper = max(g.requireUpper, pinned[ClassUpper])
+	digits = max(g.requireDigits, pinned[ClassDigit])
+	symbols = max(g.requireSymbols, pinned[ClassSymbol])
+	class, ok := g.rotatingClass()
+	if !ok {
+		return
+	}
+	switch class {
+	case ClassLower:
+		lower = max(lower, 1)
+	case ClassUpper:
+		upper = max(upper, 1)
+	case ClassDigit:
+		digits = max(digits, 1)
+	case ClassSymbol:
+		symbols = max(symbols, 1)
+	}
+	return
+}
+
+// exactTotal returns the number of positions claimed by the Exact methods.
+func (g *Generator) exactTotal() int {
+	total := 0
+	for _, exact := range []struct {
+		set bool
+		n   int
+	}{
+		{g.exactLower, g.requireLower},
+		{g.exactUpper, g.requireUpper},
+		{g.exactDigits, g.requireDigits},
+		{g.exactSymbols, g.requireSymbols},
+	} {
+		if exact.set {
+			total += exact.n
+		}
+	}
+	for _, set := range g.charsets {
+		if set.exact {
+			total += max(set.require, 0)
+		}
+	}
+	return total
+}
+
+// rotatingClass returns the class required by WithTimeSeededClassRequirement for the current
+// period, if any.
+func (g *Generator) rotatingClass() (CharClass, bool) {
+	if len(g.rotatingClasses) == 0 || g.rotationPeriod <= 0 {
+		return 0, false
+	}
+	now := time.Now
+	if g.now != nil {
+		now = g.now
+	}
+	bucket := now().UnixNano() / int64(g.rotationPeriod)
+	idx := bucket % int64(len(g.rotatingClasses))
+	if idx < 0 {
+		idx += int64(len(g.rotatingClasses))
+	}
+	return g.rotatingClasses[idx], true
+}
+
+// Deterministic reports whether the generator always generates the same password from the same
+// source of randomness, see WithRand.  A generator which rotates its required class with
+// WithTimeSeededClassRequirement depends on the time, and one with a BreachChecker on the
+// answers of the checker, so neither is deterministic.
+func (g *Generator) Deterministic() bool {
+	return len(g.rotatingClasses) == 0 && g.breachChecker == nil
+}
+
+// validateRotation checks the configuration given to WithTimeSeededClassRequirement.
+func (g *Generator) validateRotation() error {
+	if g.rotatingClasses == nil && g.rotationPeriod == 0 {
+		return nil
+	}
+	if len(g.rotatingClasses) == 0 || g.rotationPeriod <= 0 {
+		return ErrInvalidConfig
+	}
+	for _, class := range g.rotatingClasses {
+		if !class.valid() {
+			return ErrInvalidConfig
+		}
+	}
+	return nil
+}
+
+// effectivePool returns every distinct element the generator may place in a password.
+func (g *Generator) effectivePool() []string {
+	var pool []string
+	seen := map[string]bool{}
+	for _, class := range g.classes() {
+		if !class.with && class.require == 0 {
+			continue
+		}
+		for _, elm := range class.elements {
+			if !seen[elm] {
+				seen[elm] = true
+				pool = append(pool, elm)
+			}
+		}
+	}
+	for _, set := range g.charsets {
+		if !set.with && set.require == 0 {
+			continue
+		}
+		for _, elm := range g.withoutExcluded(set.elements) {
+			if !seen[elm] {
+				seen[elm] = true
+				pool = append(pool, elm)
 			}
-			buffer.WriteString(elm)
 		}
 	}
+	return pool
+}
+
+// diversityCandidates returns the pools of the enabled classes without required characters,
+// along with how many of them must be drawn from to satisfy RequireClassDiversity.
+func (g *Generator) diversityCandidates() ([][]string, int, error) {
+	if g.classDiversity <= 0 {
+		return nil, 0, nil
+	}
 
-	// We now have a buffer with the passwords elements
-	// Shuffle shuffles the password in slice, so we have to cast it to a rune slice
-	// then back to a string.
-	pass := buffer.String()
-	runePass := []rune(pass)
-	shuffle(runePass)
-	pass = string(runePass)
+	enabled, covered := 0, 0
+	var candidates [][]string
+	for _, class := range g.classes() {
+		switch {
+		case class.require > 0:
+			enabled++
+			covered++
+		case class.with && !g.excludedByMax(class.class):
+			enabled++
+			candidates = append(candidates, class.elements)
+		}
+	}
 
-	return pass, nil
+	if g.classDiversity > enabled {
+		return nil, 0, ErrInsufficientClasses
+	}
+	if covered >= g.classDiversity {
+		return nil, 0, nil
+	}
+	return candidates, g.classDiversity - covered, nil
 }
 
-// shuffle shuffles the values in the slice in place
-func shuffle(vals []rune) {
+// shuffle shuffles the values in the slice in place with randomness read from r.
+func shuffle(r io.Reader, vals []string) error {
 	for len(vals) > 0 {
 		n := len(vals)
-		randIndex, _ := rand.Int(rand.Reader, big.NewInt(int64(n)))
+		randIndex, err := rand.Int(r, big.NewInt(int64(n)))
+		if err != nil {
+			return err
+		}
 		vals[n-1], vals[randIndex.Int64()] = vals[randIndex.Int64()], vals[n-1]
 		vals = vals[:n-1]
 	}
+	return nil
 }
 
-// randomElement extracts a random element from the given string.
-func randomElement(s string) (string, error) {
-	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(s))))
+// randomElement extracts a random element from the given slice with randomness read from r.
+func randomElement(r io.Reader, s []string) (string, error) {
+	n, err := rand.Int(r, big.NewInt(int64(len(s))))
 	if err != nil {
 		return "", err
 	}
-	return string(s[n.Int64()]), nil
+	return s[n.Int64()], nil
+}
+
+// randomElement extracts a random element from the given slice with the generator's source of
+// randomness.
+func (g *Generator) randomElement(s []string) (string, error) {
+	return randomElement(g.reader(), s)
+}
+
+// reader returns the source of randomness set with WithRand, or crypto/rand.
+func (g *Generator) reader() io.Reader {
+	if g.random == nil {
+		return rand.Reader
+	}
+	return g.random
+}
+
+// symbolElements returns the symbols of the generator along with any emojis.
+func (g *Generator) symbolElements() []string {
+	return append(split(g.symbols), g.emojis...)
+}
+
+// split splits a string into its characters, one element per rune, so pools of accented,
+// Cyrillic, or CJK characters are drawn from whole characters rather than bytes.
+func split(s string) []string {
+	elements := make([]string, 0, len(s))
+	for _, r := range s {
+		elements = append(elements, string(r))
+	}
+	return elements
 }
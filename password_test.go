@@ -1,9 +1,15 @@
 package passwordgen
 
 import (
+	"errors"
 	"log"
+	"math"
 	"regexp"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+	"unicode/utf8"
 )
 
 var (
@@ -21,7 +27,7 @@ func TestGenerator_Generate(t *testing.T) {
 	t.Run("exceeds_length", func(t *testing.T) {
 		t.Parallel()
 		gen := NewGenerator().RequireDigits(5).RequireLower(5)
-		if _, err := gen.Generate(5); err != ErrExceedsTotalLength {
+		if _, err := gen.Generate(5); !errors.Is(err, ErrExceedsTotalLength) {
 			t.Errorf("expected: %q, actual: %q", err, ErrExceedsTotalLength)
 		}
 	})
@@ -29,7 +35,7 @@ func TestGenerator_Generate(t *testing.T) {
 	t.Run("no_characters", func(t *testing.T) {
 		t.Parallel()
 		gen := NewGenerator()
-		if _, err := gen.Generate(5); err != ErrNoCharactersSpecified {
+		if _, err := gen.Generate(5); !errors.Is(err, ErrNoCharactersSpecified) {
 			t.Errorf("expected: %q, actual: %q", err, ErrNoCharactersSpecified)
 		}
 	})
@@ -150,6 +156,168 @@ func TestGenerator_Generate(t *testing.T) {
 		}
 	})
 
+	t.Run("exact_then_with", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().ExactUpper(3).WithUpper().WithLower()
+		for i := 0; i < 20; i++ {
+			pass, err := gen.Generate(16)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			count := 0
+			for _, match := range containsUpper.FindAllString(pass, -1) {
+				count += len(match)
+			}
+			if count != 3 {
+				t.Errorf("Expected password %s to have exactly 3 upper characters", pass)
+			}
+		}
+	})
+
+	t.Run("exact_then_require", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().ExactUpper(3).RequireUpper(1).WithUpper()
+		pass, err := gen.Generate(16)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if !containsUpper.MatchString(pass) {
+			t.Errorf("password %s does not contain upper cap characters", pass)
+		}
+	})
+
+	t.Run("exact_exceeds_length", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().ExactLower(4).ExactUpper(4).ExactDigits(4)
+		if _, err := gen.Generate(10); !errors.Is(err, ErrExceedsTotalLength) {
+			t.Errorf("expected: %q, actual: %q", ErrExceedsTotalLength, err)
+		}
+	})
+
+	t.Run("exact_fit", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().ExactLower(4).ExactUpper(4).ExactDigits(4)
+		pass, err := gen.Generate(12)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if len(pass) != 12 {
+			t.Errorf("expected password %s to be 12 characters long", pass)
+		}
+		counts := map[CharClass]int{}
+		for _, r := range pass {
+			class, _ := classOf(r)
+			counts[class]++
+		}
+		for _, class := range []CharClass{ClassLower, ClassUpper, ClassDigit} {
+			if counts[class] != 4 {
+				t.Errorf("expected: 4 %s characters, actual: %d in %s", class, counts[class], pass)
+			}
+		}
+	})
+
+	t.Run("require_from_set", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().RequireFromSet("@#$", 1)
+		for i := 0; i < 50; i++ {
+			pass, err := gen.Generate(8)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if !strings.ContainsAny(pass, "@#$") {
+				t.Errorf("password %s does not contain any of @#$", pass)
+			}
+		}
+	})
+
+	t.Run("require_from_set_exceeds_length", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().RequireLower(3).RequireFromSet("@#$", 3)
+		if _, err := gen.Generate(5); !errors.Is(err, ErrExceedsTotalLength) {
+			t.Errorf("expected: %q, actual: %q", ErrExceedsTotalLength, err)
+		}
+		if n := gen.MinLength(); n != 6 {
+			t.Errorf("expected: %d, actual: %d", 6, n)
+		}
+	})
+
+	t.Run("require_from_empty_set", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().RequireFromSet("", 1)
+		if _, err := gen.Generate(8); err != ErrEmptyCharacterSet {
+			t.Errorf("expected: %q, actual: %q", ErrEmptyCharacterSet, err)
+		}
+	})
+
+	t.Run("max_length", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower()
+		if _, err := gen.Generate(DefaultMaxLength + 1); err != ErrExceedsMaxLength {
+			t.Errorf("expected: %q, actual: %q", ErrExceedsMaxLength, err)
+		}
+		if _, err := gen.Generate(DefaultMaxLength); err != nil {
+			t.Errorf("expected no error, received %q", err)
+		}
+
+		gen.WithMaxLength(32)
+		if _, err := gen.Generate(33); err != ErrExceedsMaxLength {
+			t.Errorf("expected: %q, actual: %q", ErrExceedsMaxLength, err)
+		}
+		if _, err := gen.Generate(31); err != nil {
+			t.Errorf("expected no error, received %q", err)
+		}
+	})
+
+	t.Run("auto_length", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().RequireDigits(5).RequireLower(5).WithAutoLength()
+		pass, err := gen.Generate(4)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if len(pass) != 10 {
+			t.Errorf("expected password %s to be 10 characters long", pass)
+		}
+		if pass, err = gen.Generate(12); err != nil || len(pass) != 12 {
+			t.Errorf("expected a 12 character password, received %q, %v", pass, err)
+		}
+		if _, err := gen.WithMaxLength(8).Generate(4); err != ErrExceedsMaxLength {
+			t.Errorf("expected: %q, actual: %q", ErrExceedsMaxLength, err)
+		}
+		if _, err := gen.WithMaxLength(0).GenerateGrouped(2, 2, "-"); !errors.Is(err, ErrExceedsTotalLength) {
+			t.Errorf("expected: %q, actual: %q", ErrExceedsTotalLength, err)
+		}
+	})
+
+	t.Run("multibyte_length", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().ExactDigits(2)
+		gen.lowerLetters = "äöüßéèñç"
+		gen.invalidate()
+		for i := 0; i < 20; i++ {
+			pass, err := gen.Generate(12)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if n := utf8.RuneCountInString(pass); n != 12 {
+				t.Errorf("expected: 12 characters, actual: %d in %s", n, pass)
+			}
+			if len(pass) <= 12 {
+				t.Errorf("expected password %s to be longer than 12 bytes", pass)
+			}
+		}
+
+		grouped, err := gen.GenerateGrouped(4, 3, "-")
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		for _, group := range strings.Split(grouped, "-") {
+			if n := utf8.RuneCountInString(group); n != 4 {
+				t.Errorf("expected: 4 characters, actual: %d in group %s", n, group)
+			}
+		}
+	})
+
 	t.Run("correct_length", func(t *testing.T) {
 		t.Parallel()
 		gen := NewGenerator().RequireLower(3).RequireLower(3).RequireDigits(3).RequireSymbols(3)
@@ -163,6 +331,841 @@ func TestGenerator_Generate(t *testing.T) {
 	})
 }
 
+func TestGenerator_NoAmbiguous(t *testing.T) {
+	t.Parallel()
+
+	// collect returns every character seen over many generated passwords.
+	collect := func(t *testing.T, gen *Generator) string {
+		seen := map[rune]bool{}
+		for i := 0; i < 100; i++ {
+			pass, err := gen.Generate(32)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			for _, r := range pass {
+				seen[r] = true
+			}
+		}
+		chars := ""
+		for r := range seen {
+			chars += string(r)
+		}
+		return chars
+	}
+
+	t.Run("digits", func(t *testing.T) {
+		t.Parallel()
+		chars := collect(t, NewGenerator().NoAmbiguousDigits().WithLower().WithDigits())
+		if strings.ContainsAny(chars, "01") {
+			t.Errorf("expected no ambiguous digits, saw %s", chars)
+		}
+		if !strings.ContainsRune(chars, 'l') {
+			t.Errorf("expected ambiguous letters to remain available, saw %s", chars)
+		}
+	})
+
+	t.Run("letters", func(t *testing.T) {
+		t.Parallel()
+		chars := collect(t, NewGenerator().NoAmbiguousLetters().WithLower().WithUpper().WithDigits())
+		if strings.ContainsAny(chars, "ilo"+"ILO") {
+			t.Errorf("expected no ambiguous letters, saw %s", chars)
+		}
+		if !strings.ContainsAny(chars, "01") {
+			t.Errorf("expected ambiguous digits to remain available, saw %s", chars)
+		}
+	})
+
+	t.Run("required", func(t *testing.T) {
+		t.Parallel()
+		// Only required characters, so none are drawn from the fill pool.
+		chars := collect(t, NewGenerator().NoAmbiguousCharacters().
+			ExactLower(8).ExactUpper(8).ExactDigits(8).ExactSymbols(8))
+		if strings.ContainsAny(chars, "ilo"+"ILO"+"01") {
+			t.Errorf("expected no ambiguous characters, saw %s", chars)
+		}
+		for _, r := range chars {
+			if !strings.ContainsRune(LowerLettersNoAmbig+UpperLettersNoAmbig+DigitsNoAmbig+SymbolsNoAmbig, r) {
+				t.Errorf("character %c is not part of the unambiguous pools", r)
+			}
+		}
+	})
+
+	t.Run("all", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().NoAmbiguousCharacters()
+		expected := NewGenerator().NoAmbiguousLetters().NoAmbiguousDigits().NoAmbiguousSymbols()
+		if !gen.Equal(expected) {
+			t.Errorf("expected: %+v, actual: %+v", expected.ToConfig(), gen.ToConfig())
+		}
+	})
+}
+
+func TestGenerator_WithShellSafeSymbols(t *testing.T) {
+	t.Parallel()
+
+	gen := NewGenerator().WithShellSafeSymbols().WithSymbols().WithLower()
+	for i := 0; i < 100; i++ {
+		pass, err := gen.Generate(32)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if strings.ContainsAny(pass, "`$\\\"'!*&;|<>(){}[]~#?^ ") {
+			t.Errorf("password %s contains a symbol which is unsafe in shells", pass)
+		}
+	}
+}
+
+func TestGenerator_WithEmojiSet(t *testing.T) {
+	t.Parallel()
+
+	emojis := []string{"🔥", "👍🏽", "🇳🇴"}
+	gen := NewGenerator().WithLower().WithEmojiSet(emojis).RequireSymbols(4)
+	seen := 0
+	for i := 0; i < 50; i++ {
+		pass, err := gen.Generate(10)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		count := 0
+		rest := pass
+		for _, emoji := range emojis {
+			count += strings.Count(rest, emoji)
+			rest = strings.Replace(rest, emoji, "", -1)
+		}
+		seen += count
+		// Whatever is left over must be whole ASCII characters, no stray parts of an emoji.
+		for _, r := range rest {
+			if r > 127 {
+				t.Errorf("password %s contains a partial emoji", pass)
+			}
+		}
+		if total := count + len(rest); total != 10 {
+			t.Errorf("Expected password %s to be 10 characters long, actual: %d", pass, total)
+		}
+	}
+	if seen == 0 {
+		t.Error("expected emojis to appear in the generated passwords")
+	}
+}
+
+func TestGenerator_WithCustomSymbols(t *testing.T) {
+	t.Parallel()
+
+	t.Run("required_symbols", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithCustomSymbols("#").RequireSymbols(3).WithLower()
+		for i := 0; i < 20; i++ {
+			pass, err := gen.Generate(8)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if strings.Count(pass, "#") < 3 {
+				t.Errorf("Expected password %s to have at least 3 # symbols", pass)
+			}
+			if strings.ContainsAny(pass, strings.Replace(Symbols, "#", "", 1)) {
+				t.Errorf("password %s contains symbols outside of the custom set", pass)
+			}
+		}
+	})
+
+	t.Run("exact_symbols", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithCustomSymbols("#").ExactSymbols(3).WithLower()
+		pass, err := gen.Generate(8)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if strings.Count(pass, "#") != 3 {
+			t.Errorf("Expected password %s to have exactly 3 # symbols", pass)
+		}
+	})
+
+	t.Run("fill_symbols", func(t *testing.T) {
+		t.Parallel()
+		custom := "!@#$"
+		gen := NewGenerator().WithCustomSymbols(custom).WithSymbols()
+		for i := 0; i < 20; i++ {
+			pass, err := gen.Generate(16)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			for _, r := range pass {
+				if !strings.ContainsRune(custom, r) {
+					t.Errorf("character %c is not part of the custom symbols %s", r, custom)
+				}
+			}
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithCustomSymbols("").RequireSymbols(1)
+		if _, err := gen.Generate(8); !errors.Is(err, ErrEmptyCharacterSet) {
+			t.Errorf("expected: %q, actual: %q", ErrEmptyCharacterSet, err)
+		}
+	})
+}
+
+func TestGenerator_UnicodePools(t *testing.T) {
+	t.Parallel()
+
+	for name, chars := range map[string]string{
+		"accented": "àáâãäåçèéêëìíîïñòóôõöùúûüý",
+		"cyrillic": "абвгдеёжзийклмнопрстуфхцчшщъыьэюя",
+		"cjk":      "的一是不了人我在有他这中大来上国个到说们",
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			gen := NewGenerator().WithDigits().AddCharset(name, chars).RequireCharset(name, 4).
+				WithCustomSymbols("。、！？").RequireSymbols(2)
+			for i := 0; i < 20; i++ {
+				pass, err := gen.GenerateChecked(16)
+				if err != nil {
+					t.Fatalf("expected no error, received %q", err)
+				}
+				if !utf8.ValidString(pass) {
+					t.Errorf("password %q isn't valid UTF-8", pass)
+				}
+				if n := utf8.RuneCountInString(pass); n != 16 {
+					t.Errorf("expected: 16 characters, actual: %d in %s", n, pass)
+				}
+				for _, r := range pass {
+					if !strings.ContainsRune(chars+Digits+"。、！？", r) {
+						t.Errorf("character %c is not part of the pool", r)
+					}
+				}
+			}
+
+			extended, err := gen.Extend("abc", 10)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if n := utf8.RuneCountInString(extended); !utf8.ValidString(extended) || n != 10 {
+				t.Errorf("expected: 10 characters, actual: %d in %q", n, extended)
+			}
+		})
+	}
+}
+
+func TestGenerator_ExcludeCharacters(t *testing.T) {
+	t.Parallel()
+
+	t.Run("every_pool", func(t *testing.T) {
+		t.Parallel()
+		excluded := `"'\aA0`
+		gen := NewGenerator().WithLower().WithUpper().WithDigits().WithSymbols().
+			RequireLower(2).RequireUpper(2).RequireDigits(2).RequireSymbols(2).
+			AddCharset("quotes", `"'`+"`").RequireFromSet(`\/`, 1).ExcludeCharacters(excluded)
+		for i := 0; i < 100; i++ {
+			pass, err := gen.GenerateChecked(32)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if strings.ContainsAny(pass, excluded) {
+				t.Errorf("password %s contains excluded characters %s", pass, excluded)
+			}
+		}
+	})
+
+	t.Run("accumulates", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithDigits().ExcludeCharacters("01234").ExcludeCharacters("5678")
+		pass, err := gen.Generate(8)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if pass != "99999999" {
+			t.Errorf("expected: %s, actual: %s", "99999999", pass)
+		}
+	})
+
+	t.Run("pool_size", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithDigits().ExcludeCharacters("01")
+		if size := gen.PoolSize(); size != 8 {
+			t.Errorf("expected: %d, actual: %d", 8, size)
+		}
+	})
+
+	t.Run("required_letters", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().ExactLower(20).ExactUpper(20).ExcludeCharacters("abcXYZ")
+		for i := 0; i < 20; i++ {
+			pass, err := gen.GenerateChecked(40)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if strings.ContainsAny(pass, "abcXYZ") {
+				t.Errorf("password %s contains excluded characters", pass)
+			}
+		}
+	})
+
+	t.Run("empty_class", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().RequireDigits(1).ExcludeCharacters(Digits)
+		if _, err := gen.Generate(8); !errors.Is(err, ErrEmptyCharacterSet) {
+			t.Errorf("expected: %q, actual: %q", ErrEmptyCharacterSet, err)
+		}
+	})
+}
+
+func TestGenerator_WithLocaleDigits(t *testing.T) {
+	t.Parallel()
+
+	t.Run("arabic_indic", func(t *testing.T) {
+		t.Parallel()
+		arabicIndic := "٠١٢٣٤٥٦٧٨٩"
+		gen := NewGenerator().WithLower().WithLocaleDigits(arabicIndic).RequireDigits(3)
+		for i := 0; i < 20; i++ {
+			pass, err := gen.Generate(10)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			digits := 0
+			for _, r := range pass {
+				if strings.ContainsRune(arabicIndic, r) {
+					digits++
+				}
+			}
+			if digits < 3 {
+				t.Errorf("Expected password %s to have at least 3 Arabic-Indic digits", pass)
+			}
+			if containsDigits.MatchString(pass) {
+				t.Errorf("password %s contains ASCII digits", pass)
+			}
+			if n := len([]rune(pass)); n != 10 {
+				t.Errorf("Expected password %s to be 10 characters long, actual: %d", pass, n)
+			}
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithLocaleDigits("")
+		if _, err := gen.Generate(10); err != ErrEmptyCharacterSet {
+			t.Errorf("expected: %q, actual: %q", ErrEmptyCharacterSet, err)
+		}
+	})
+}
+
+func TestGenerator_UnusedPoolCharacters(t *testing.T) {
+	t.Parallel()
+
+	t.Run("covers_pool", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().NoAmbiguousCharacters().WithLower().WithDigits()
+		pool := LowerLettersNoAmbig + DigitsNoAmbig
+		pass, err := gen.Generate(12)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		unused := gen.UnusedPoolCharacters(pass)
+		for _, r := range pool {
+			if strings.ContainsRune(pass, r) == strings.ContainsRune(unused, r) {
+				t.Errorf("expected %c to be either used in %s or unused in %s", r, pass, unused)
+			}
+		}
+		for _, r := range pass + unused {
+			if !strings.ContainsRune(pool, r) {
+				t.Errorf("character %c is not part of the pool %s", r, pool)
+			}
+		}
+	})
+
+	t.Run("nothing_used", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithDigits()
+		if unused := gen.UnusedPoolCharacters(""); unused != Digits {
+			t.Errorf("expected: %s, actual: %s", Digits, unused)
+		}
+		if unused := gen.UnusedPoolCharacters(Digits); unused != "" {
+			t.Errorf("expected no unused characters, actual: %s", unused)
+		}
+	})
+}
+
+func TestGenerator_Clone(t *testing.T) {
+	t.Parallel()
+
+	t.Run("independent", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().RequireFromSet("#", 1).PinPosition(0, ClassLower)
+		clone := gen.Clone().RequireFromSet("!", 1).PinPosition(1, ClassDigit).WithEmojiSet([]string{"🙂"})
+		if gen.Equal(clone) {
+			t.Error("expected the clone to be configured independently")
+		}
+		if len(gen.requiredSets) != 1 || len(gen.pins) != 1 || len(gen.emojis) != 0 {
+			t.Error("expected the original configuration to be unchanged")
+		}
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithDigits().RequireFromSet("#", 1)
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				// Generate from the shared generator while configuring a clone of it.
+				clone := gen.Clone().RequireFromSet("!", 1).PinPosition(0, ClassDigit)
+				for j := 0; j < 20; j++ {
+					if _, err := gen.Generate(12); err != nil {
+						t.Errorf("expected no error, received %q", err)
+					}
+					pass, err := clone.Generate(12)
+					if err != nil {
+						t.Errorf("expected no error, received %q", err)
+					} else if !containsDigits.MatchString(pass[:1]) {
+						t.Errorf("Expected password %s to start with a digit", pass)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+func TestGenerator_GenerateDefault(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default_length", func(t *testing.T) {
+		t.Parallel()
+		pass, err := NewGenerator().WithLower().GenerateDefault()
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if len(pass) != DefaultLength {
+			t.Errorf("expected: %d, actual: %d", DefaultLength, len(pass))
+		}
+	})
+
+	t.Run("with_length", func(t *testing.T) {
+		t.Parallel()
+		pass, err := NewGenerator().WithLower().WithLength(24).GenerateDefault()
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if len(pass) != 24 {
+			t.Errorf("expected: %d, actual: %d", 24, len(pass))
+		}
+	})
+}
+
+func TestGenerator_PoolSize(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no_ambiguous", func(t *testing.T) {
+		t.Parallel()
+		expected := len([]rune(LowerLettersNoAmbig)) + len([]rune(DigitsNoAmbig))
+		if size := NewGenerator().NoAmbiguousCharacters().WithLower().WithDigits().PoolSize(); size != expected {
+			t.Errorf("expected: %d, actual: %d", expected, size)
+		}
+	})
+
+	t.Run("all_classes", func(t *testing.T) {
+		t.Parallel()
+		expected := len(LowerLetters + UpperLetters + Digits + Symbols)
+		if size := NewGenerator().WithLower().WithUpper().WithDigits().WithSymbols().PoolSize(); size != expected {
+			t.Errorf("expected: %d, actual: %d", expected, size)
+		}
+	})
+
+	t.Run("no_classes", func(t *testing.T) {
+		t.Parallel()
+		if size := NewGenerator().PoolSize(); size != 0 {
+			t.Errorf("expected: %d, actual: %d", 0, size)
+		}
+	})
+}
+
+func TestGenerator_GenerateSatisfying(t *testing.T) {
+	t.Parallel()
+
+	startsWithSeven := func(pass string) bool { return strings.HasPrefix(pass, "7") }
+
+	t.Run("satisfies_validator", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithDigits().WithMaxAttempts(500)
+		pass, err := gen.GenerateSatisfying(6, startsWithSeven)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if !startsWithSeven(pass) {
+			t.Errorf("password %s does not start with 7", pass)
+		}
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower()
+		if _, err := gen.GenerateSatisfying(6, startsWithSeven); err != ErrMaxAttemptsExceeded {
+			t.Errorf("expected: %q, actual: %q", ErrMaxAttemptsExceeded, err)
+		}
+	})
+}
+
+func TestGenerator_GenerateAvoiding(t *testing.T) {
+	t.Parallel()
+
+	t.Run("avoided", func(t *testing.T) {
+		t.Parallel()
+		// Two letter passwords from a two letter pool contain "ab" a quarter of the time.
+		gen := NewGenerator().WithLower().WithUpper().WithMaxAttempts(500)
+		gen.lowerLetters, gen.upperLetters = "ab", "AB"
+		gen.invalidate()
+		for i := 0; i < 50; i++ {
+			pass, err := gen.GenerateAvoiding(2, []string{"Ab", ""})
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if strings.EqualFold(pass, "ab") {
+				t.Errorf("password %s contains the forbidden substring", pass)
+			}
+		}
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithDigits()
+		gen.digits = "7"
+		gen.invalidate()
+		if _, err := gen.GenerateAvoiding(4, []string{"77"}); err != ErrMaxAttemptsExceeded {
+			t.Errorf("expected: %q, actual: %q", ErrMaxAttemptsExceeded, err)
+		}
+	})
+}
+
+func TestGenerator_WithMaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	errNoLetter := errors.New("no letter")
+	for _, tc := range []struct {
+		name     string
+		max      int
+		expected int
+	}{
+		{"custom", 3, 3},
+		{"default", 0, DefaultMaxAttempts},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			// A digits only pool can never satisfy a validator requiring a letter.
+			gen := NewGenerator().WithDigits().WithMaxAttempts(tc.max)
+			calls := 0
+			_, err := gen.GenerateValidated(4, func(pass string) error {
+				calls++
+				if !containsLower.MatchString(pass) {
+					return errNoLetter
+				}
+				return nil
+			})
+			if !errors.Is(err, ErrMaxAttemptsExceeded) {
+				t.Errorf("expected: %q, actual: %q", ErrMaxAttemptsExceeded, err)
+			}
+			if calls != tc.expected {
+				t.Errorf("expected %d attempts, actual: %d", tc.expected, calls)
+			}
+		})
+	}
+}
+
+func TestGenerator_RequireClassDiversity(t *testing.T) {
+	t.Parallel()
+
+	t.Run("all_classes_present", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithUpper().WithDigits().RequireClassDiversity(3)
+		for i := 0; i < 200; i++ {
+			pass, err := gen.Generate(3)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if !containsLower.MatchString(pass) || !containsUpper.MatchString(pass) || !containsDigits.MatchString(pass) {
+				t.Errorf("password %s does not contain all three classes", pass)
+			}
+		}
+	})
+
+	t.Run("counts_required_classes", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().RequireSymbols(1).WithLower().WithUpper().WithDigits().RequireClassDiversity(2)
+		for i := 0; i < 200; i++ {
+			pass, err := gen.Generate(2)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			classes := 0
+			for _, re := range []*regexp.Regexp{containsLower, containsUpper, containsDigits, containsSymnbols} {
+				if re.MatchString(pass) {
+					classes++
+				}
+			}
+			if classes < 2 {
+				t.Errorf("password %s contains fewer than 2 classes", pass)
+			}
+		}
+	})
+
+	t.Run("too_many_classes", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithUpper().RequireClassDiversity(3)
+		if _, err := gen.Generate(10); err != ErrInsufficientClasses {
+			t.Errorf("expected: %q, actual: %q", ErrInsufficientClasses, err)
+		}
+	})
+
+	t.Run("exceeds_length", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().RequireDigits(2).WithLower().WithUpper().RequireClassDiversity(3)
+		if _, err := gen.Generate(3); !errors.Is(err, ErrExceedsTotalLength) {
+			t.Errorf("expected: %q, actual: %q", ErrExceedsTotalLength, err)
+		}
+	})
+}
+
+func TestGenerator_GenerateGrouped(t *testing.T) {
+	t.Parallel()
+
+	t.Run("groups", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithUpper().WithDigits()
+		code, err := gen.GenerateGrouped(4, 3, "-")
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if len(code) != 14 {
+			t.Errorf("Expected code %s to be 14 characters long", code)
+		}
+		for _, i := range []int{4, 9} {
+			if code[i] != '-' {
+				t.Errorf("Expected code %s to have a separator at position %d", code, i)
+			}
+		}
+		groups := strings.Split(code, "-")
+		if len(groups) != 3 {
+			t.Fatalf("Expected code %s to have 3 groups", code)
+		}
+		for _, group := range groups {
+			if len(group) != 4 {
+				t.Errorf("Expected group %s of code %s to be 4 characters long", group, code)
+			}
+		}
+	})
+
+	t.Run("separator_excluded_from_requirements", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().ExactSymbols(2).WithLower()
+		code, err := gen.GenerateGrouped(3, 2, "--")
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		count := 0
+		for _, match := range containsSymnbols.FindAllString(strings.Replace(code, "--", "", 1), -1) {
+			count += len(match)
+		}
+		if count != 2 {
+			t.Errorf("Expected code %s to have exactly 2 symbols outside the separator", code)
+		}
+	})
+
+	t.Run("invalid_grouping", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower()
+		if _, err := gen.GenerateGrouped(0, 3, "-"); err != ErrInvalidGrouping {
+			t.Errorf("expected: %q, actual: %q", ErrInvalidGrouping, err)
+		}
+	})
+}
+
+func TestGenerator_WithTimeSeededClassRequirement(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2019, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("rotates_each_period", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithTimeSeededClassRequirement([]CharClass{ClassDigit, ClassSymbol}, time.Hour)
+		required := map[CharClass]*regexp.Regexp{ClassDigit: containsDigits, ClassSymbol: containsSymnbols}
+		seen := map[CharClass]bool{}
+		for period := 0; period < 4; period++ {
+			now := start.Add(time.Duration(period) * time.Hour)
+			gen.now = func() time.Time { return now }
+			class, _ := gen.rotatingClass()
+			seen[class] = true
+			for i := 0; i < 5; i++ {
+				// Passwords in the same period always require the same class.
+				gen.now = func() time.Time { return now.Add(time.Duration(i) * 10 * time.Minute) }
+				if current, _ := gen.rotatingClass(); current != class {
+					t.Errorf("expected class %s for the period, actual: %s", class, current)
+				}
+				pass, err := gen.Generate(8)
+				if err != nil {
+					t.Fatalf("expected no error, received %q", err)
+				}
+				if !required[class].MatchString(pass) {
+					t.Errorf("password %s does not contain a %s character", pass, class)
+				}
+			}
+		}
+		if !seen[ClassDigit] || !seen[ClassSymbol] {
+			t.Errorf("expected the required class to change across periods, saw %v", seen)
+		}
+	})
+
+	t.Run("invalid_configuration", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithTimeSeededClassRequirement([]CharClass{ClassDigit}, 0)
+		if _, err := gen.Generate(8); err != ErrInvalidConfig {
+			t.Errorf("expected: %q, actual: %q", ErrInvalidConfig, err)
+		}
+		gen = NewGenerator().WithLower().WithTimeSeededClassRequirement(nil, time.Hour)
+		if _, err := gen.Generate(8); err != ErrInvalidConfig {
+			t.Errorf("expected: %q, actual: %q", ErrInvalidConfig, err)
+		}
+	})
+}
+
+func TestGenerator_Deterministic(t *testing.T) {
+	t.Parallel()
+
+	gen := NewGenerator().WithLower().WithDigits()
+	if !gen.Deterministic() {
+		t.Error("expected the generator to be deterministic")
+	}
+	if gen.Clone().WithTimeSeededClassRequirement([]CharClass{ClassDigit}, time.Hour).Deterministic() {
+		t.Error("expected a rotating generator not to be deterministic")
+	}
+	if gen.Clone().WithBreachCheck(&fakeBreachChecker{}).Deterministic() {
+		t.Error("expected a breach checking generator not to be deterministic")
+	}
+}
+
+func TestGenerator_MinLength(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sum_of_requirements", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().RequireLower(2).RequireUpper(3).RequireDigits(1).ExactSymbols(4)
+		if min := gen.MinLength(); min != 10 {
+			t.Errorf("expected: %d, actual: %d", 10, min)
+		}
+		pass, err := gen.Generate(gen.MinLength())
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if len(pass) != 10 {
+			t.Errorf("Expected password %s to be 10 characters long", pass)
+		}
+		if _, err := gen.Generate(gen.MinLength() - 1); !errors.Is(err, ErrExceedsTotalLength) {
+			t.Errorf("expected: %q, actual: %q", ErrExceedsTotalLength, err)
+		}
+	})
+
+	t.Run("class_diversity", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().RequireDigits(2).WithLower().WithUpper().RequireClassDiversity(3)
+		if min := gen.MinLength(); min != 4 {
+			t.Errorf("expected: %d, actual: %d", 4, min)
+		}
+		if _, err := gen.Generate(gen.MinLength()); err != nil {
+			t.Errorf("expected no error, received %q", err)
+		}
+	})
+}
+
+func TestGenerator_GenerateFromLengthDistribution(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid_distribution", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower()
+		for _, weights := range []map[int]int{nil, {16: 0}, {0: 1}, {-1: 1}, {8: math.MaxInt, 9: math.MaxInt}} {
+			if _, err := gen.GenerateFromLengthDistribution(weights); err != ErrInvalidLengthDistribution {
+				t.Errorf("expected: %q, actual: %q", ErrInvalidLengthDistribution, err)
+			}
+		}
+	})
+
+	t.Run("exceeds_length", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().RequireDigits(5).RequireLower(5)
+		if _, err := gen.GenerateFromLengthDistribution(map[int]int{16: 1, 8: 1}); !errors.Is(err, ErrExceedsTotalLength) {
+			t.Errorf("expected: %q, actual: %q", ErrExceedsTotalLength, err)
+		}
+	})
+
+	t.Run("matches_weights", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithDigits()
+		weights := map[int]int{16: 3, 20: 1}
+		samples := 4000
+		counts := map[int]int{}
+		for i := 0; i < samples; i++ {
+			pass, err := gen.GenerateFromLengthDistribution(weights)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			counts[len(pass)]++
+		}
+		if len(counts) != 2 {
+			t.Fatalf("expected only lengths 16 and 20, received %v", counts)
+		}
+		// Expected share of length 16 is 0.75, allow a generous margin so the test isn't flaky.
+		if share := float64(counts[16]) / float64(samples); share < 0.70 || share > 0.80 {
+			t.Errorf("expected length 16 in roughly 75%% of passwords, received %.2f%%", share*100)
+		}
+	})
+}
+
+func TestGenerator_GenerateValidated(t *testing.T) {
+	t.Parallel()
+
+	errNoLeadingDigit := errors.New("no digit in the first three characters")
+	leadingDigit := func(pass string) error {
+		if !containsDigits.MatchString(pass[:3]) {
+			return errNoLeadingDigit
+		}
+		return nil
+	}
+
+	t.Run("passes_validator", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithUpper().WithDigits()
+		for i := 0; i < 20; i++ {
+			pass, err := gen.GenerateValidated(10, leadingDigit)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if leadingDigit(pass) != nil {
+				t.Errorf("password %s does not contain a digit in the first three characters", pass)
+			}
+		}
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower()
+		_, err := gen.GenerateValidated(10, leadingDigit)
+		if !errors.Is(err, errNoLeadingDigit) {
+			t.Errorf("expected: %q, actual: %q", errNoLeadingDigit, err)
+		}
+		if !errors.Is(err, ErrMaxAttemptsExceeded) {
+			t.Errorf("expected: %q, actual: %q", ErrMaxAttemptsExceeded, err)
+		}
+	})
+
+	t.Run("generate_error", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator()
+		if _, err := gen.GenerateValidated(10, leadingDigit); !errors.Is(err, ErrNoCharactersSpecified) {
+			t.Errorf("expected: %q, actual: %q", ErrNoCharactersSpecified, err)
+		}
+	})
+}
+
 func ExampleGenerator_Generate() {
 	pass, err := NewGenerator().WithUpper().WithLower().WithDigits().WithLower().Generate(8)
 	if err != nil {
@@ -0,0 +1,245 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidPattern is the error returned when a pattern has a dangling
+// escape, an unterminated character set, or a malformed repeat count
+var ErrInvalidPattern = errors.New("invalid pattern")
+
+// GenerateFromPattern will generate a password from a KeePass style pattern, where every
+// placeholder is replaced by a random character from its set and all other characters are
+// kept as is.  For example Cvcv-9999-LLLL gives passwords like Bara-4821-QdTx.  The
+// placeholders are:
+//
+//	a  lower case letters and digits
+//	A  letters of both cases and digits
+//	U  upper case letters and digits
+//	d  digits, 9 is the same
+//	h  lower case hexadecimal digits
+//	H  upper case hexadecimal digits
+//	l  lower case letters
+//	L  letters of both cases, as in KeePass, use u for upper case letters only
+//	u  upper case letters
+//	v  lower case vowels
+//	V  vowels of both cases
+//	Z  upper case vowels
+//	c  lower case consonants
+//	C  consonants of both cases
+//	z  upper case consonants
+//	p  punctuation, ,.;:
+//	b  brackets, ()[]{}<>
+//	s  symbols
+//	S  letters of both cases, digits, and symbols
+//	x  Latin-1 Supplement characters, ¡ to ÿ without the soft hyphen
+//
+// A backslash keeps the next character as is, e.g. \d is a literal d.  [...] is a custom set,
+// a random character from the union of the placeholders and characters inside the brackets,
+// e.g. [dh] for a digit or lower case hex digit or [u_] for an upper case letter or an
+// underscore.  Inside a set, ^ removes the placeholders and characters following it, e.g.
+// [d^13] is a digit other than 1 and 3, and a backslash keeps the next character as is, e.g.
+// [\]\^] is ] or ^.  {n} after a placeholder, set, or literal repeats it n times, e.g. d{4} is
+// 4 digits.
+//
+// The sets use the generator's character sets, with exclusions and ambiguous characters
+// removed, whether or not the class is enabled.  The Require and Exact counts aren't applied.
+// Passwords longer than the maximum length return ErrExceedsMaxLength.
+func (g *Generator) GenerateFromPattern(pattern string) (string, error) {
+	var b strings.Builder
+	length := 0
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		pool, ok := g.patternPool(r)
+		switch r {
+		case '\\':
+			if i++; i == len(runes) {
+				return "", fmt.Errorf("%w: trailing \\", ErrInvalidPattern)
+			}
+			r, pool, ok = runes[i], nil, false
+		case '[':
+			set, end, err := g.patternSet(runes[i+1:])
+			if err != nil {
+				return "", err
+			}
+			pool, ok = set, true
+			i += 1 + end
+		}
+
+		count := 1
+		if i+1 < len(runes) && runes[i+1] == '{' {
+			end := slices.Index(runes[i+1:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("%w: unterminated repeat count", ErrInvalidPattern)
+			}
+			n, err := strconv.Atoi(string(runes[i+2 : i+1+end]))
+			if err != nil || n < 0 {
+				return "", fmt.Errorf("%w: repeat count %q", ErrInvalidPattern, string(runes[i+2:i+1+end]))
+			}
+			count = n
+			i += 1 + end
+		}
+		// Checked before adding and writing so huge repeat counts can't overflow the length or
+		// exhaust memory.
+		if count > g.maxPasswordLength()-length {
+			return "", ErrExceedsMaxLength
+		}
+		length += count
+
+		if !ok {
+			b.WriteString(strings.Repeat(string(r), count))
+			continue
+		}
+		if len(pool) == 0 {
+			return "", ErrEmptyCharacterSet
+		}
+		for j := 0; j < count; j++ {
+			elm, err := g.randomElement(pool)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(elm)
+		}
+	}
+	return b.String(), nil
+}
+
+// patternPool returns the characters a pattern placeholder is replaced by, and false if r
+// isn't a placeholder.
+func (g *Generator) patternPool(r rune) ([]string, bool) {
+	pools := g.pools()
+	lower, upper := pools.elements[ClassLower], pools.elements[ClassUpper]
+	digits, symbols := pools.elements[ClassDigit], pools.elements[ClassSymbol]
+	vowel := func(elm string) bool { return strings.ContainsAny(elm, "aeiouAEIOU") }
+	consonant := func(elm string) bool { return !vowel(elm) }
+
+	switch r {
+	case 'a':
+		return slices.Concat(lower, digits), true
+	case 'A':
+		return slices.Concat(lower, upper, digits), true
+	case 'U':
+		return slices.Concat(upper, digits), true
+	case 'd', '9':
+		return digits, true
+	case 'h':
+		return hexElements(pools, ClassLower), true
+	case 'H':
+		return hexElements(pools, ClassUpper), true
+	case 'l':
+		return lower, true
+	case 'L':
+		return slices.Concat(lower, upper), true
+	case 'u':
+		return upper, true
+	case 'v':
+		return filterElements(lower, vowel), true
+	case 'V':
+		return filterElements(slices.Concat(lower, upper), vowel), true
+	case 'Z':
+		return filterElements(upper, vowel), true
+	case 'c':
+		return filterElements(lower, consonant), true
+	case 'C':
+		return filterElements(slices.Concat(lower, upper), consonant), true
+	case 'z':
+		return filterElements(upper, consonant), true
+	case 'p':
+		return g.withoutExcluded(split(",.;:")), true
+	case 'b':
+		return g.withoutExcluded(split("()[]{}<>")), true
+	case 's':
+		return symbols, true
+	case 'S':
+		return slices.Concat(lower, upper, digits, symbols), true
+	case 'x':
+		return g.withoutExcluded(latin1Supplement()), true
+	}
+	return nil, false
+}
+
+// patternSet returns the characters of the custom set [...] of a pattern, given the runes
+// after the opening bracket, and the index of the closing bracket.
+func (g *Generator) patternSet(runes []rune) ([]string, int, error) {
+	var include, exclude []string
+	excluding := false
+	for i := 0; i < len(runes); i++ {
+		var elements []string
+		switch r := runes[i]; r {
+		case ']':
+			set := filterElements(distinctElements(include), func(elm string) bool {
+				return !slices.Contains(exclude, elm)
+			})
+			return set, i, nil
+		case '^':
+			excluding = true
+			continue
+		case '\\':
+			if i++; i == len(runes) {
+				return nil, 0, fmt.Errorf("%w: trailing \\", ErrInvalidPattern)
+			}
+			elements = g.withoutExcluded([]string{string(runes[i])})
+		default:
+			pool, ok := g.patternPool(r)
+			if !ok {
+				pool = g.withoutExcluded([]string{string(r)})
+			}
+			elements = pool
+		}
+		if excluding {
+			exclude = append(exclude, elements...)
+		} else {
+			include = append(include, elements...)
+		}
+	}
+	return nil, 0, fmt.Errorf("%w: unterminated character set", ErrInvalidPattern)
+}
+
+// distinctElements returns the elements without duplicates, in the order they first appear.
+func distinctElements(elements []string) []string {
+	var unique []string
+	for _, elm := range elements {
+		if !slices.Contains(unique, elm) {
+			unique = append(unique, elm)
+		}
+	}
+	return unique
+}
+
+// filterElements returns the elements for which keep returns true.
+func filterElements(elements []string, keep func(string) bool) []string {
+	var kept []string
+	for _, elm := range elements {
+		if keep(elm) {
+			kept = append(kept, elm)
+		}
+	}
+	return kept
+}
+
+// latin1Supplement returns the printable characters of the Latin-1 Supplement block, ¡ to ÿ,
+// without the invisible soft hyphen.
+func latin1Supplement() []string {
+	var elements []string
+	for r := rune(0xA1); r <= 0xFF; r++ {
+		if r != 0xAD {
+			elements = append(elements, string(r))
+		}
+	}
+	return elements
+}
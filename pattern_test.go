@@ -0,0 +1,113 @@
+package passwordgen
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestGenerator_GenerateFromPattern(t *testing.T) {
+	t.Parallel()
+
+	t.Run("placeholders", func(t *testing.T) {
+		t.Parallel()
+		for pattern, expected := range map[string]string{
+			"Cvcv-9999-LLLL": "^[B-DF-HJ-NP-TV-Zb-df-hj-np-tv-z][aeiou][b-df-hj-np-tv-z][aeiou]-[0-9]{4}-[A-Za-z]{4}$",
+			"aAUdhH":         "^[a-z0-9][A-Za-z0-9][A-Z0-9][0-9][0-9a-f][0-9A-F]$",
+			"lLuvVZczpb":     "^[a-z][A-Za-z][A-Z][aeiou][aeiouAEIOU][AEIOU][b-df-hj-np-tv-z][B-DF-HJ-NP-TV-Z][,.;:][()\\[\\]{}<>]$",
+		} {
+			pass, err := NewGenerator().GenerateFromPattern(pattern)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if !regexp.MustCompile(expected).MatchString(pass) {
+				t.Errorf("password %s does not match the pattern %s", pass, pattern)
+			}
+		}
+	})
+
+	t.Run("escape_and_repeat", func(t *testing.T) {
+		t.Parallel()
+		pass, err := NewGenerator().GenerateFromPattern(`\d\\d{4}-{3}u{0}`)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if !regexp.MustCompile(`^d\\[0-9]{4}---$`).MatchString(pass) {
+			t.Errorf("password %s does not match the pattern", pass)
+		}
+	})
+
+	t.Run("custom_sets", func(t *testing.T) {
+		t.Parallel()
+		for pattern, expected := range map[string]string{
+			"[dh]{8}":    "^[0-9a-f]{8}$",
+			"[u_]{8}":    "^[A-Z_]{8}$",
+			"[d^13]{16}": "^[02-9]{16}$",
+			`[\]\^]{8}`:  `^[\]^]{8}$`,
+			`[\d\\]{8}`:  `^[d\\]{8}$`,
+		} {
+			pass, err := NewGenerator().GenerateFromPattern(pattern)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if !regexp.MustCompile(expected).MatchString(pass) {
+				t.Errorf("password %s does not match the pattern %s", pass, pattern)
+			}
+		}
+		if _, err := NewGenerator().GenerateFromPattern("[d^d]"); err != ErrEmptyCharacterSet {
+			t.Errorf("expected: %q, actual: %q", ErrEmptyCharacterSet, err)
+		}
+	})
+
+	t.Run("hex_exclusions", func(t *testing.T) {
+		t.Parallel()
+		pass, err := NewGenerator().NoAmbiguousDigits().ExcludeCharacters("9fF").GenerateFromPattern("h{32}H{32}")
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if !regexp.MustCompile("^[2-8a-e]{32}[2-8A-E]{32}$").MatchString(pass) {
+			t.Errorf("password %s does not match the pattern", pass)
+		}
+	})
+
+	t.Run("latin1_supplement", func(t *testing.T) {
+		t.Parallel()
+		pass, err := NewGenerator().ExcludeCharacters("ÿ").GenerateFromPattern("x{64}")
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if !regexp.MustCompile("^[\u00A1-\u00AC\u00AE-\u00FE]{64}$").MatchString(pass) {
+			t.Errorf("password %s does not match the pattern", pass)
+		}
+	})
+
+	t.Run("letters_of_both_cases", func(t *testing.T) {
+		t.Parallel()
+		pass, err := NewGenerator().GenerateFromPattern("L{256}")
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if !regexp.MustCompile("^[A-Za-z]{256}$").MatchString(pass) || !regexp.MustCompile("[a-z]").MatchString(pass) || !regexp.MustCompile("[A-Z]").MatchString(pass) {
+			t.Errorf("password %s isn't letters of both cases", pass)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+		for _, pattern := range []string{`dd\`, "d{4", "d{x}", "d{-1}", "[dh", `[d\`} {
+			if _, err := NewGenerator().GenerateFromPattern(pattern); !errors.Is(err, ErrInvalidPattern) {
+				t.Errorf("expected: %q, actual: %q", ErrInvalidPattern, err)
+			}
+		}
+		for _, pattern := range []string{"d{1000000000}", "ad{" + strconv.Itoa(math.MaxInt) + "}"} {
+			if _, err := NewGenerator().GenerateFromPattern(pattern); err != ErrExceedsMaxLength {
+				t.Errorf("expected: %q, actual: %q", ErrExceedsMaxLength, err)
+			}
+		}
+		if _, err := NewGenerator().ExcludeCharacters("aeiou").GenerateFromPattern("v"); err != ErrEmptyCharacterSet {
+			t.Errorf("expected: %q, actual: %q", ErrEmptyCharacterSet, err)
+		}
+	})
+}
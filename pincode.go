@@ -0,0 +1,71 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrWeakPIN is the error returned by CheckPIN for a PIN made of a guessable
+// pattern
+var ErrWeakPIN = errors.New("PIN follows a guessable pattern")
+
+// GeneratePIN will generate a PIN of length digits, see DefaultPIN.
+func GeneratePIN(length int) (string, error) {
+	return DefaultPIN().Generate(length)
+}
+
+// GenerateStrongPIN will generate a PIN of length digits which doesn't follow one of the
+// guessable patterns rejected by CheckPIN, e.g. 1111, 1234, 4646, or 1987.
+func GenerateStrongPIN(length int) (string, error) {
+	return DefaultPIN().GenerateValidated(length, CheckPIN)
+}
+
+// CheckPIN returns an error wrapping ErrWeakPIN if pin is made of a single repeated digit, such
+// as 1111, of ascending or descending consecutive digits, such as 1234 or 9876, of a repeated
+// block of digits, such as 4646 or 123123, or is 4 digits which could be a year from 1900 to
+// 2099.
+func CheckPIN(pin string) error {
+	switch {
+	case len(pin) > 1 && strings.Count(pin, pin[:1]) == len(pin):
+		return fmt.Errorf("%w: repeated digit", ErrWeakPIN)
+	case len(pin) > 2 && (isDigitSequence(pin, 1) || isDigitSequence(pin, -1)):
+		return fmt.Errorf("%w: sequence of digits", ErrWeakPIN)
+	case isRepeatedBlock(pin):
+		return fmt.Errorf("%w: repeated block of digits", ErrWeakPIN)
+	case len(pin) == 4 && (strings.HasPrefix(pin, "19") || strings.HasPrefix(pin, "20")):
+		return fmt.Errorf("%w: year", ErrWeakPIN)
+	}
+	return nil
+}
+
+// isDigitSequence returns whether every digit of pin is step more than the previous one.
+func isDigitSequence(pin string, step int) bool {
+	for i := 1; i < len(pin); i++ {
+		if int(pin[i])-int(pin[i-1]) != step {
+			return false
+		}
+	}
+	return true
+}
+
+// isRepeatedBlock returns whether pin repeats a block of at least 2 digits at least twice, i.e.
+// every digit is the one a period before it, such as 4646, 46464, or 123123.
+func isRepeatedBlock(pin string) bool {
+	for period := 2; period <= len(pin)/2; period++ {
+		if pin[period:] == pin[:len(pin)-period] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package passwordgen
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGeneratePIN(t *testing.T) {
+	t.Parallel()
+
+	pin, err := GeneratePIN(6)
+	if err != nil {
+		t.Fatalf("expected no error, received %q", err)
+	}
+	if len(pin) != 6 || len(containsDigits.FindString(pin)) != 6 {
+		t.Errorf("Expected PIN %s to be 6 digits", pin)
+	}
+}
+
+func TestGenerateStrongPIN(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 200; i++ {
+		pin, err := GenerateStrongPIN(4)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if err := CheckPIN(pin); err != nil {
+			t.Errorf("expected PIN %s to be strong, received %q", pin, err)
+		}
+	}
+}
+
+func TestCheckPIN(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		pin  string
+		weak bool
+	}{
+		{"1111", true},
+		{"000000", true},
+		{"1234", true},
+		{"3456789", true},
+		{"9876", true},
+		{"1987", true},
+		{"2024", true},
+		{"4646", true},
+		{"46464", true},
+		{"123123", true},
+		{"907907", true},
+		{"7", false},
+		{"12", false},
+		{"1357", false},
+		{"8202", false},
+		{"190000", false},
+		{"1123", false},
+		{"464", false},
+		{"4664", false},
+		{"123412", false},
+	} {
+		if err := CheckPIN(tt.pin); errors.Is(err, ErrWeakPIN) != tt.weak {
+			t.Errorf("expected PIN %s weak: %t, received %v", tt.pin, tt.weak, err)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import (
+	"fmt"
+	"slices"
+)
+
+// GenerationPlan describes how a generator allocates the positions of a password before any
+// characters are drawn.
+type GenerationPlan struct {
+	// Length is the requested length of the password.
+	Length int
+
+	// Lower, Upper, Digits, and Symbols are the positions reserved for each class by the
+	// Require and Exact methods.
+	Lower   int
+	Upper   int
+	Digits  int
+	Symbols int
+
+	// Sets is the positions reserved by RequireFromSet.
+	Sets int
+
+	// Diversity is the positions reserved by RequireClassDiversity, each is drawn from a
+	// different class picked at random when the password is generated.
+	Diversity int
+
+	// Free is the positions left over, which are filled from Pool.
+	Free int
+
+	// Pool is the characters the free positions are drawn from.
+	Pool string
+
+	fill       []string
+	candidates [][]string
+	symbolCap  int
+	caps       [4]int
+	capped     [4]bool
+}
+
+// Reserved returns the number of positions reserved by requirements.
+func (p *GenerationPlan) Reserved() int {
+	return p.Lower + p.Upper + p.Digits + p.Symbols + p.Sets + p.Diversity
+}
+
+// Plan returns how the generator would allocate the positions of a password at the specified
+// length.  It runs the same validation as Generate and returns the same errors, but doesn't
+// draw any characters.
+func (g *Generator) Plan(length int) (*GenerationPlan, error) {
+	plan, err := g.plan(length)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := g.validateEntropy(plan); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// plan allocates the positions of a password like Plan, without checking the entropy set with
+// WithMinEntropy.
+func (g *Generator) plan(length int) (*GenerationPlan, error) {
+	if g.autoLength {
+		length = max(length, g.MinLength())
+	}
+
+	if length > g.maxPasswordLength() {
+		return nil, ErrExceedsMaxLength
+	}
+
+	if err := g.validateCharsets(); err != nil {
+		return nil, err
+	}
+
+	// Exact<type> clears the With flag of its class, so a generator made only of Exact
+	// counts or RequireFromSet still has characters to draw from.
+	if !g.withLower && !g.withUpper && !g.withDigits && !g.withSymbols && len(g.charsetFill()) == 0 &&
+		g.requireLower+g.requireUpper+g.requireDigits+g.requireSymbols+g.requiredFromSets() == 0 {
+		return nil, &NoCharactersError{Unfilled: length, Requested: length}
+	}
+
+	if err := g.validateCounts(length); err != nil {
+		return nil, err
+	}
+
+	// Exact counts can't be met by a shorter password, report them before anything else.
+	if exact := g.exactTotal(); exact > length {
+		return nil, &LengthError{Required: exact, Requested: length}
+	}
+
+	if err := g.validateRotation(); err != nil {
+		return nil, err
+	}
+
+	for _, weight := range g.classWeights {
+		if weight < 0 || weight > MaxClassWeight {
+			return nil, ErrInvalidConfig
+		}
+	}
+
+	if err := g.validateCaseRatio(); err != nil {
+		return nil, err
+	}
+
+	for _, class := range g.classes() {
+		if (class.with || class.require > 0) && len(class.elements) == 0 {
+			return nil, ErrEmptyCharacterSet
+		}
+	}
+
+	for _, set := range g.setRequirements() {
+		if set.n > 0 && len(set.elements) == 0 {
+			return nil, ErrEmptyCharacterSet
+		}
+	}
+
+	if err := g.validateExactSets(); err != nil {
+		return nil, err
+	}
+
+	candidates, missing, err := g.diversityCandidates()
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &GenerationPlan{Length: length, Sets: g.requiredFromSets(), Diversity: missing, candidates: candidates}
+	plan.Lower, plan.Upper, plan.Digits, plan.Symbols = g.requiredCounts()
+	if plan.Reserved() > length {
+		return nil, &LengthError{Required: plan.Reserved(), Requested: length}
+	}
+	plan.Free = length - plan.Reserved()
+
+	pools := g.pools()
+	plan.fill = pools.fill
+	// The only reason this could be empty is Exact<type> was used and we don't have enough
+	// reserved characters to fill the password.  Error out as an invalid password generator
+	// was created.
+	if plan.Free > 0 && len(plan.fill) == 0 {
+		return nil, &NoCharactersError{Unfilled: plan.Free, Requested: length}
+	}
+	plan.Pool = pools.pool
+
+	if err := g.validateSymbolFraction(plan); err != nil {
+		return nil, err
+	}
+
+	if err := g.validateClassMax(plan); err != nil {
+		return nil, err
+	}
+
+	if err := g.validateUnique(plan); err != nil {
+		return nil, err
+	}
+
+	if err := g.validatePositions(plan); err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// validateCounts returns a LengthError for the first count of the requirements which is more
+// than length on its own.  Checking every count before plan adds them up keeps the sums from
+// overflowing into a length which passes the checks.
+func (g *Generator) validateCounts(length int) error {
+	counts := []int{g.requireLower, g.requireUpper, g.requireDigits, g.requireSymbols, g.classDiversity}
+	for _, set := range g.setRequirements() {
+		counts = append(counts, set.n)
+	}
+	for _, n := range counts {
+		if n > length {
+			return &LengthError{Required: n, Requested: length}
+		}
+	}
+	return nil
+}
+
+// validateExactSets returns an error wrapping ErrInvalidConfig if a set required with
+// RequireFromSet contains characters of a class whose count is set with an Exact method.  The
+// draws from the set would add to the exact count, so the password could have more characters
+// of the class than the exact count allows.
+func (g *Generator) validateExactSets() error {
+	elements := g.pools().elements
+	for class, exact := range [4]bool{g.exactLower, g.exactUpper, g.exactDigits, g.exactSymbols} {
+		if !exact {
+			continue
+		}
+		for _, set := range g.setRequirements() {
+			if set.n > 0 && slices.ContainsFunc(set.elements, func(elm string) bool {
+				return slices.Contains(elements[class], elm)
+			}) {
+				return fmt.Errorf("%w: a required set overlaps the exact %s characters", ErrInvalidConfig, CharClass(class))
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package passwordgen
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestGenerator_Plan(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reserved_and_free", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().RequireLower(2).ExactDigits(3).WithUpper().WithSymbols().RequireClassDiversity(4)
+		plan, err := gen.Plan(16)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if plan.Lower != 2 || plan.Upper != 0 || plan.Digits != 3 || plan.Symbols != 0 || plan.Diversity != 2 {
+			t.Errorf("unexpected reserved positions: %+v", plan)
+		}
+		if plan.Reserved()+plan.Free != 16 {
+			t.Errorf("expected reserved (%d) + free (%d) to equal 16", plan.Reserved(), plan.Free)
+		}
+		if expected := LowerLetters + UpperLetters + Symbols; plan.Pool != expected {
+			t.Errorf("expected: %s, actual: %s", expected, plan.Pool)
+		}
+	})
+
+	t.Run("same_errors_as_generate", func(t *testing.T) {
+		t.Parallel()
+		for _, gen := range []*Generator{
+			NewGenerator(),
+			NewGenerator().RequireDigits(5).RequireLower(5),
+			NewGenerator().ExactUpper(2).WithLower().WithClassWeights(0, 1, 0, 0),
+			NewGenerator().WithLower().RequireClassDiversity(2),
+		} {
+			_, planErr := gen.Plan(8)
+			_, genErr := gen.Generate(8)
+			if planErr == nil || !reflect.DeepEqual(planErr, genErr) {
+				t.Errorf("expected: %q, actual: %q", genErr, planErr)
+			}
+		}
+	})
+
+	t.Run("overflowing_counts", func(t *testing.T) {
+		t.Parallel()
+		n := math.MaxInt/4 + 1
+		for name, gen := range map[string]*Generator{
+			"classes": NewGenerator().RequireLower(n).RequireUpper(n).RequireDigits(n).RequireSymbols(n),
+			"sets":    NewGenerator().WithLower().RequireFromSet("abc", math.MaxInt).RequireFromSet("xyz", math.MaxInt),
+		} {
+			_, err := gen.Plan(16)
+			var lengthErr *LengthError
+			if !errors.As(err, &lengthErr) || lengthErr.Required <= 16 {
+				t.Errorf("%s: expected a LengthError, received %q", name, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrPolicyViolation is the error returned by Policy.Validate when a password
+// doesn't satisfy the policy
+var ErrPolicyViolation = errors.New("password violates the policy")
+
+// Policy is a password policy which drives both the generation of passwords and the
+// verification of passwords chosen by users, so both always agree.  The zero value of a field
+// doesn't constrain passwords.  Generated passwords are drawn from the default classes, while
+// Validate also accepts letters and digits of other scripts, and counts any other printable
+// character as a symbol, e.g. "." or a space.  A policy can be stored as JSON or YAML and turned
+// into a generator with Generator.
+type Policy struct {
+	// MinLength and MaxLength bound the number of characters of the password.
+	MinLength int `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	MaxLength int `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+
+	// MinLower, MinUpper, MinDigits, and MinSymbols are the least number of characters of
+	// each class in the password.
+	MinLower   int `json:"minLower,omitempty" yaml:"minLower,omitempty"`
+	MinUpper   int `json:"minUpper,omitempty" yaml:"minUpper,omitempty"`
+	MinDigits  int `json:"minDigits,omitempty" yaml:"minDigits,omitempty"`
+	MinSymbols int `json:"minSymbols,omitempty" yaml:"minSymbols,omitempty"`
+
+	// MinLetters is the least number of letters of either case in the password.
+	MinLetters int `json:"minLetters,omitempty" yaml:"minLetters,omitempty"`
+
+	// MinClasses is the least number of classes present in the password.
+	MinClasses int `json:"minClasses,omitempty" yaml:"minClasses,omitempty"`
+
+	// Forbidden is the characters which may not be in the password.
+	Forbidden string `json:"forbidden,omitempty" yaml:"forbidden,omitempty"`
+
+	// MaxRepeat is the most times a character may be repeated in a row, e.g. 2 allows "aa"
+	// but not "aaa".
+	MaxRepeat int `json:"maxRepeat,omitempty" yaml:"maxRepeat,omitempty"`
+}
+
+// Validate returns an error wrapping ErrPolicyViolation describing the first rule of the
+// policy the password doesn't satisfy, or nil if it satisfies all of them.
+func (p Policy) Validate(password string) error {
+	length := utf8.RuneCountInString(password)
+	if length < p.MinLength {
+		return fmt.Errorf("%w: %d characters, at least %d are required", ErrPolicyViolation, length, p.MinLength)
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		return fmt.Errorf("%w: %d characters, at most %d are allowed", ErrPolicyViolation, length, p.MaxLength)
+	}
+
+	lower, upper, digits, symbols := countPolicyClasses(password)
+	for _, class := range []struct {
+		class      CharClass
+		count, min int
+	}{
+		{ClassLower, lower, p.MinLower},
+		{ClassUpper, upper, p.MinUpper},
+		{ClassDigit, digits, p.MinDigits},
+		{ClassSymbol, symbols, p.MinSymbols},
+	} {
+		if class.count < class.min {
+			return fmt.Errorf("%w: %d %s characters, at least %d are required",
+				ErrPolicyViolation, class.count, class.class, class.min)
+		}
+	}
+	if letters := lower + upper; letters < p.MinLetters {
+		return fmt.Errorf("%w: %d letters, at least %d are required", ErrPolicyViolation, letters, p.MinLetters)
+	}
+	present := 0
+	for _, count := range []int{lower, upper, digits, symbols} {
+		if count > 0 {
+			present++
+		}
+	}
+	if present < p.MinClasses {
+		return fmt.Errorf("%w: %d classes, at least %d are required", ErrPolicyViolation, present, p.MinClasses)
+	}
+
+	if i := strings.IndexAny(password, p.Forbidden); i >= 0 {
+		r, _ := utf8.DecodeRuneInString(password[i:])
+		return fmt.Errorf("%w: %q is forbidden", ErrPolicyViolation, r)
+	}
+	if run := longestRun(split(password)); p.MaxRepeat > 0 && run > p.MaxRepeat {
+		return fmt.Errorf("%w: a character is repeated %d times in a row, at most %d are allowed",
+			ErrPolicyViolation, run, p.MaxRepeat)
+	}
+	return nil
+}
+
+// Generator returns a generator of passwords which satisfy the policy, drawn from the default
+// classes without the forbidden characters.  GenerateDefault generates passwords of
+// MinLength, or DefaultLength if it is longer, limited to MaxLength.
+func (p Policy) Generator() *Generator {
+	g := NewGenerator().WithLower().WithUpper().WithDigits().WithSymbols().
+		RequireLower(p.MinLower).RequireUpper(p.MinUpper).RequireDigits(p.MinDigits).RequireSymbols(p.MinSymbols).
+		RequireClassDiversity(p.MinClasses).ExcludeCharacters(p.Forbidden).WithMaxLength(p.MaxLength)
+	if p.MinLetters > 0 {
+		g.RequireFromSet(LowerLetters+UpperLetters, p.MinLetters)
+	}
+
+	length := max(p.MinLength, DefaultLength)
+	if p.MaxLength > 0 {
+		length = min(length, p.MaxLength)
+	}
+	g.maxRepeat = p.MaxRepeat
+	return g.WithLength(length)
+}
+
+// PolicyNIST80063B returns the policy of NIST SP 800-63B revision 4 for passwords used as a
+// single authentication factor: at least 15 characters, at least 64 characters supported, and
+// no composition rules.
+func PolicyNIST80063B() Policy {
+	return Policy{MinLength: 15, MaxLength: 64}
+}
+
+// PolicyPCIDSS returns the policy of PCI DSS 4.0 requirement 8.3.6: at least 12 characters
+// with both letters and digits.
+func PolicyPCIDSS() Policy {
+	return Policy{MinLength: 12, MinLetters: 1, MinDigits: 1}
+}
+
+// PolicyActiveDirectory returns the policy of the default Active Directory password complexity
+// requirements: 7 to 256 characters from at least 3 of the lower case, upper case, digit, and
+// symbol classes.  As in Active Directory, any character which isn't a letter or a digit is a
+// symbol, e.g. "password.1" satisfies the policy.  The rule against containing the account name can't be expressed by a
+// policy and has to be checked separately.
+func PolicyActiveDirectory() Policy {
+	return Policy{MinLength: 7, MaxLength: 256, MinClasses: 3}
+}
+
+// countPolicyClasses counts the characters of the password in each class for Validate.  Unlike
+// CountClasses it isn't limited to the default classes, users type passwords from the whole
+// keyboard, so any printable character which isn't a letter or a digit is a symbol.
+func countPolicyClasses(password string) (lower, upper, digits, symbols int) {
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower++
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsDigit(r):
+			digits++
+		case unicode.IsPrint(r) && !unicode.IsLetter(r):
+			symbols++
+		}
+	}
+	return
+}
+
+// longestRun returns the length of the longest run of identical elements.
+func longestRun(elements []string) int {
+	longest, run := 0, 0
+	for i, elm := range elements {
+		if i > 0 && elm == elements[i-1] {
+			run++
+		} else {
+			run = 1
+		}
+		longest = max(longest, run)
+	}
+	return longest
+}
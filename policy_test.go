@@ -0,0 +1,195 @@
+package passwordgen
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPolicy_Validate(t *testing.T) {
+	t.Parallel()
+
+	policy := Policy{
+		MinLength:  8,
+		MaxLength:  16,
+		MinLower:   1,
+		MinUpper:   1,
+		MinDigits:  2,
+		MinSymbols: 1,
+		Forbidden:  `"'\`,
+		MaxRepeat:  2,
+	}
+	for _, tt := range []struct {
+		password string
+		valid    bool
+	}{
+		{"aB3$5cde", true},
+		{"aaB3$5cd", true},
+		{"aB3$5cd", false},
+		{"aB3$5cdefghijklmn", false},
+		{"ab3$5cde", false},
+		{"AB3$5CDE", false},
+		{"aB3$bcde", false},
+		{"aB35cdef", false},
+		{`aB3$5cd"`, false},
+		{"aaaB3$5c", false},
+		{"aB3.5cde", true},
+		{"aB35cd e", true},
+		{"éB3?5cde", true},
+	} {
+		err := policy.Validate(tt.password)
+		if tt.valid && err != nil {
+			t.Errorf("expected password %s to be valid, received %q", tt.password, err)
+		}
+		if !tt.valid && !errors.Is(err, ErrPolicyViolation) {
+			t.Errorf("expected password %s to violate the policy, received %v", tt.password, err)
+		}
+	}
+
+	if err := (Policy{}).Validate(""); err != nil {
+		t.Errorf("expected no error, received %q", err)
+	}
+
+	// Punctuation outside of the default Symbols counts as a symbol.
+	for _, symbol := range []string{".", "?", ",", "'", " ", "/", ":", "€"} {
+		if err := (Policy{MinSymbols: 1}).Validate("Password1" + symbol); err != nil {
+			t.Errorf("expected %q to count as a symbol, received %q", symbol, err)
+		}
+	}
+	if err := (Policy{MinSymbols: 1}).Validate("Password1\t"); !errors.Is(err, ErrPolicyViolation) {
+		t.Errorf("expected a tab not to count as a symbol, received %v", err)
+	}
+}
+
+func TestPolicy_Generator(t *testing.T) {
+	t.Parallel()
+
+	t.Run("satisfies_policy", func(t *testing.T) {
+		t.Parallel()
+		policy := Policy{MinLength: 20, MaxLength: 24, MinUpper: 3, MinDigits: 3, MinSymbols: 2, Forbidden: `"'\`, MaxRepeat: 1}
+		gen := policy.Generator()
+		for i := 0; i < 100; i++ {
+			pass, err := gen.GenerateDefault()
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if len(pass) != 20 {
+				t.Errorf("Expected password %s to be 20 characters long", pass)
+			}
+			if err := policy.Validate(pass); err != nil {
+				t.Errorf("expected password %s to satisfy the policy, received %q", pass, err)
+			}
+		}
+		if _, err := gen.Generate(25); !errors.Is(err, ErrExceedsMaxLength) {
+			t.Errorf("expected: %q, actual: %q", ErrExceedsMaxLength, err)
+		}
+	})
+
+	t.Run("length", func(t *testing.T) {
+		t.Parallel()
+		for _, tt := range []struct {
+			policy   Policy
+			expected int
+		}{
+			{Policy{}, DefaultLength},
+			{Policy{MinLength: 32}, 32},
+			{Policy{MaxLength: 12}, 12},
+		} {
+			pass, err := tt.policy.Generator().GenerateDefault()
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if len(pass) != tt.expected {
+				t.Errorf("expected: %d, actual: %d", tt.expected, len(pass))
+			}
+		}
+	})
+
+	t.Run("max_repeat", func(t *testing.T) {
+		t.Parallel()
+		gen := Policy{MaxRepeat: 1}.Generator()
+		for i := 0; i < 50; i++ {
+			pass, err := gen.GenerateChecked(64)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if longestRun(split(pass)) > 1 {
+				t.Errorf("password %s repeats a character", pass)
+			}
+		}
+	})
+}
+
+func TestPolicy_JSON(t *testing.T) {
+	t.Parallel()
+	policy := Policy{MinLength: 10, MaxLength: 32, MinUpper: 1, MinDigits: 2, MinClasses: 3, Forbidden: "0O", MaxRepeat: 2}
+	data, err := json.Marshal(policy)
+	if err != nil {
+		t.Fatalf("expected no error, received %q", err)
+	}
+	if expected := `{"minLength":10,"maxLength":32,"minUpper":1,"minDigits":2,"minClasses":3,"forbidden":"0O","maxRepeat":2}`; string(data) != expected {
+		t.Errorf("expected: %q, actual: %q", expected, data)
+	}
+
+	var restored Policy
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatalf("expected no error, received %q", err)
+	}
+	if restored != policy {
+		t.Errorf("expected: %+v, actual: %+v", policy, restored)
+	}
+	pass, err := restored.Generator().GenerateDefault()
+	if err != nil {
+		t.Fatalf("expected no error, received %q", err)
+	}
+	if err := policy.Validate(pass); err != nil {
+		t.Errorf("expected no error, received %q", err)
+	}
+}
+
+func TestPolicyPresets(t *testing.T) {
+	t.Parallel()
+
+	for name, tt := range map[string]struct {
+		policy  Policy
+		valid   []string
+		invalid []string
+	}{
+		"nist": {PolicyNIST80063B(), []string{"correcthorsebattery"}, []string{"Sh0rt!pass", strings.Repeat("a", 65)}},
+		"pci":  {PolicyPCIDSS(), []string{"ABCDEFGHIJK1", "abcdefghijk1"}, []string{"abcdefghijkl", "123456789012", "abc123"}},
+		"ad":   {PolicyActiveDirectory(), []string{"Abcdef1", "abc12$x", "password.1", "Pass word"}, []string{"Abcdefg", "Ab1$", "password1"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			for _, pass := range tt.valid {
+				if err := tt.policy.Validate(pass); err != nil {
+					t.Errorf("expected password %s to be valid, received %q", pass, err)
+				}
+			}
+			for _, pass := range tt.invalid {
+				if err := tt.policy.Validate(pass); !errors.Is(err, ErrPolicyViolation) {
+					t.Errorf("expected password %s to violate the policy, received %v", pass, err)
+				}
+			}
+			gen := tt.policy.Generator()
+			for i := 0; i < 50; i++ {
+				pass, err := gen.GenerateDefault()
+				if err != nil {
+					t.Fatalf("expected no error, received %q", err)
+				}
+				if err := tt.policy.Validate(pass); err != nil {
+					t.Errorf("expected password %s to satisfy the policy, received %q", pass, err)
+				}
+			}
+			// The shortest passwords allowed by the policy satisfy it too.
+			pass, err := gen.Generate(tt.policy.MinLength)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if err := tt.policy.Validate(pass); err != nil {
+				t.Errorf("expected password %s to satisfy the policy, received %q", pass, err)
+			}
+		})
+	}
+}
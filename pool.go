@@ -0,0 +1,65 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import "sync"
+
+// GeneratorPool is a pool of generators cloned from a template, which is safe for use by
+// multiple goroutines.  Each generator taken from the pool can be tweaked for a single request
+// without affecting the template or other goroutines, and is reused once it is put back.
+type GeneratorPool struct {
+	template *Generator
+	pool     sync.Pool
+}
+
+// NewGeneratorPool returns a pool of clones of the given template.  Changes made to the
+// template after the pool is created do not affect the pool.
+func NewGeneratorPool(template *Generator) *GeneratorPool {
+	p := &GeneratorPool{template: template.Clone()}
+	p.pool.New = func() interface{} {
+		return p.template.Clone()
+	}
+	return p
+}
+
+// Get returns a generator configured as the template.
+func (p *GeneratorPool) Get() *Generator {
+	return p.pool.Get().(*Generator)
+}
+
+// Put returns a generator to the pool.  The generator is reset to the template configuration
+// so changes made after Get do not leak into later requests.
+func (p *GeneratorPool) Put(g *Generator) {
+	g.resetTo(p.template)
+	p.pool.Put(g)
+}
+
+// resetTo replaces the configuration of g with the one of template in place.  The slices of g
+// are reused to hold copies of the template's, so no generator shares the slices of the
+// template or another and a reset doesn't allocate once g has grown to the template's size.
+func (g *Generator) resetTo(template *Generator) {
+	rotatingClasses, pins, emojis := g.rotatingClasses[:0], g.pins[:0], g.emojis[:0]
+	requiredSets, charsets := g.requiredSets[:0], g.charsets[:0]
+
+	*g = *template
+	g.rotatingClasses = append(rotatingClasses, template.rotatingClasses...)
+	g.pins = append(pins, template.pins...)
+	g.emojis = append(emojis, template.emojis...)
+	g.requiredSets = append(requiredSets, template.requiredSets...)
+	g.charsets = append(charsets, template.charsets...)
+}
+
+// Generate will generate a password at the specified length using a generator from the pool.
+func (p *GeneratorPool) Generate(length int) (string, error) {
+	g := p.Get()
+	defer p.Put(g)
+	return g.Generate(length)
+}
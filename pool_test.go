@@ -0,0 +1,132 @@
+package passwordgen
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestGeneratorPool(t *testing.T) {
+	t.Parallel()
+
+	t.Run("concurrent_use", func(t *testing.T) {
+		t.Parallel()
+		pool := NewGeneratorPool(NewGenerator().RequireDigits(2).WithLower())
+		var wg sync.WaitGroup
+		errs := make(chan error, 50)
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				for j := 0; j < 20; j++ {
+					if i%2 == 0 {
+						if _, err := pool.Generate(12); err != nil {
+							errs <- err
+							return
+						}
+						continue
+					}
+					// Tweak a generator for a single request.
+					gen := pool.Get()
+					_, err := gen.RequireUpper(2).Generate(12)
+					pool.Put(gen)
+					if err != nil {
+						errs <- err
+						return
+					}
+				}
+			}(i)
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			t.Errorf("expected no error, received %q", err)
+		}
+	})
+
+	t.Run("concurrent_tweaks", func(t *testing.T) {
+		t.Parallel()
+		pool := NewGeneratorPool(NewGenerator().WithLower().RequireFromSet("#", 1).PinPosition(0, ClassLower))
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 20; j++ {
+					gen := pool.Get()
+					gen.RequireFromSet("!", 1).PinPosition(1, ClassLower)
+					if _, err := gen.Generate(8); err != nil {
+						t.Errorf("expected no error, received %q", err)
+					}
+					pool.Put(gen)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("put_resets_configuration", func(t *testing.T) {
+		t.Parallel()
+		pool := NewGeneratorPool(NewGenerator().WithLower())
+		gen := pool.Get()
+		gen.ExactDigits(5)
+		pool.Put(gen)
+		for i := 0; i < 10; i++ {
+			pass, err := pool.Generate(10)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if containsDigits.MatchString(pass) {
+				t.Errorf("password %s contains digits from a previous request", pass)
+			}
+		}
+	})
+}
+
+// TestGeneratorPool_Put isn't parallel, AllocsPerRun can't measure allocations while other tests
+// run.
+func TestGeneratorPool_Put(t *testing.T) {
+	template := NewGenerator().WithLower().WithEmojiSet([]string{"🙂", "🙃"}).RequireFromSet("xyz", 1).
+		PinPosition(0, ClassLower)
+	pool := NewGeneratorPool(template)
+	gen := pool.Get()
+	gen.WithEmojiSet([]string{"🚀"}).RequireFromSet("abc", 2)
+	allocs := testing.AllocsPerRun(100, func() {
+		gen.resetTo(pool.template)
+	})
+	if allocs != 0 {
+		t.Errorf("expected: %d, actual: %.0f allocations", 0, allocs)
+	}
+	if !gen.Equal(pool.template) {
+		t.Error("expected the generator to be reset to the template")
+	}
+	gen.WithEmojiSet([]string{"🚀"})
+	gen.resetTo(pool.template)
+	gen.emojis[0] = "🚀"
+	if pool.template.emojis[0] != "🙂" {
+		t.Errorf("expected: %q, actual: %q", "🙂", pool.template.emojis[0])
+	}
+}
+
+func BenchmarkGeneratorPool_Generate(b *testing.B) {
+	pool := NewGeneratorPool(NewGenerator().WithLower().WithUpper().WithDigits().WithSymbols())
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := pool.Generate(16); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkGenerator_GenerateShared(b *testing.B) {
+	gen := NewGenerator().WithLower().WithUpper().WithDigits().WithSymbols()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := gen.Generate(16); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,316 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// ErrUnsatisfiablePosition is the error returned when no character the generator
+// can produce satisfies a positional constraint
+var ErrUnsatisfiablePosition = errors.New("no available character satisfies the positional constraint")
+
+// ForbidLeadingDigit ensures the password never starts with a digit, for parsers which would
+// treat the password as a number.  A digit drawn into the first position is swapped with a
+// character from another class, symbols are still allowed at the front.  Generate returns
+// ErrUnsatisfiablePosition if digits are the only enabled class, or if more digits are required
+// than fit after the first position.
+func (g *Generator) ForbidLeadingDigit() *Generator {
+	g.forbidLeadingDigit = true
+	return g
+}
+
+// ForbidBoundarySymbols ensures neither the first nor the last character of the password is a
+// symbol, for systems which trim them or lose them when the password is copied.  Symbols drawn
+// into either position are swapped with characters from other classes.  Generate returns
+// ErrUnsatisfiablePosition if symbols are the only enabled class, or if more symbols are
+// required than fit between the first and last positions.
+func (g *Generator) ForbidBoundarySymbols() *Generator {
+	g.forbidBoundarySymbols = true
+	return g
+}
+
+// NoSymbolAtEnds is the same as ForbidBoundarySymbols.
+func (g *Generator) NoSymbolAtEnds() *Generator {
+	return g.ForbidBoundarySymbols()
+}
+
+// StartWithLetter ensures the password starts with a lower or upper case letter, for systems
+// which reject passwords beginning with a digit or symbol.  A letter elsewhere in the password
+// is swapped into the first position, and passwords without one are regenerated.  Generate
+// returns ErrUnsatisfiablePosition if neither letter class is enabled, or if the required
+// digits and symbols leave no position for the first letter.
+func (g *Generator) StartWithLetter() *Generator {
+	g.startWithLetter = true
+	return g
+}
+
+// PinPosition forces the character at index to be from class, e.g. PinPosition(0, ClassUpper)
+// for passwords which must start with an upper case letter.  Pins act as requirements, at
+// least one character of the class is reserved for every position pinned to it.  Generate
+// returns ErrUnsatisfiablePosition if index is outside the password, is pinned to another
+// class, or conflicts with ForbidLeadingDigit or ForbidBoundarySymbols.
+func (g *Generator) PinPosition(index int, class CharClass) *Generator {
+	g.pins = append(g.pins, pin{index: index, class: class})
+	return g
+}
+
+// pin is a position pinned to a class by PinPosition.
+type pin struct {
+	index int
+	class CharClass
+}
+
+// pinnedAt returns the class position i is pinned to, if any.
+func (g *Generator) pinnedAt(i int) (CharClass, bool) {
+	for _, p := range g.pins {
+		if p.index == i {
+			return p.class, true
+		}
+	}
+	return 0, false
+}
+
+// pinnedCounts returns the number of distinct positions pinned to each class.
+func (g *Generator) pinnedCounts() [4]int {
+	var counts [4]int
+	seen := map[int]bool{}
+	for _, p := range g.pins {
+		if !seen[p.index] && p.class.valid() {
+			seen[p.index] = true
+			counts[p.class]++
+		}
+	}
+	return counts
+}
+
+// hasPositionalConstraints returns whether any positional constraint is set.
+func (g *Generator) hasPositionalConstraints() bool {
+	return g.forbidLeadingDigit || g.forbidBoundarySymbols || g.startWithLetter || len(g.pins) > 0
+}
+
+// constrainedPositions returns the positions of a password of the specified length which are
+// subject to a positional constraint.
+func (g *Generator) constrainedPositions(length int) []int {
+	if length == 0 || !g.hasPositionalConstraints() {
+		return nil
+	}
+	var positions []int
+	if g.forbidLeadingDigit || g.forbidBoundarySymbols || g.startWithLetter {
+		positions = append(positions, 0)
+	}
+	if g.forbidBoundarySymbols && length > 1 {
+		positions = append(positions, length-1)
+	}
+	// Pins are fixed after the boundaries so a swap into a pinned position can't be undone.
+	for _, p := range g.pins {
+		if p.index >= 0 && p.index < length && !slices.Contains(positions, p.index) {
+			positions = append(positions, p.index)
+		}
+	}
+	return positions
+}
+
+// allowedAt returns whether elm may be placed at position i of a password of the specified
+// length.
+func (g *Generator) allowedAt(i, length int, elm string) bool {
+	if g.forbidLeadingDigit && i == 0 && isDigit(elm) {
+		return false
+	}
+	if g.forbidBoundarySymbols && (i == 0 || i == length-1) && g.isSymbol(elm) {
+		return false
+	}
+	if g.startWithLetter && i == 0 && !g.isLetter(elm) {
+		return false
+	}
+	if class, ok := g.pinnedAt(i); ok && !slices.Contains(g.pools().elements[class], elm) {
+		return false
+	}
+	return true
+}
+
+// classAllowedAt returns whether characters of class may be placed at position i of a password
+// of the specified length.
+func (g *Generator) classAllowedAt(class CharClass, i, length int) bool {
+	if g.forbidLeadingDigit && i == 0 && class == ClassDigit {
+		return false
+	}
+	if g.forbidBoundarySymbols && (i == 0 || i == length-1) && class == ClassSymbol {
+		return false
+	}
+	if g.startWithLetter && i == 0 && class != ClassLower && class != ClassUpper {
+		return false
+	}
+	return true
+}
+
+// validatePositions checks that every positional constraint can be satisfied by the plan.
+func (g *Generator) validatePositions(plan *GenerationPlan) error {
+	if err := g.validatePins(plan); err != nil {
+		return err
+	}
+	if err := g.validatePlacement(plan); err != nil {
+		return err
+	}
+	for _, i := range g.constrainedPositions(plan.Length) {
+		if _, ok := g.pinnedAt(i); ok {
+			continue
+		}
+		satisfiable := false
+		for _, class := range g.classes() {
+			if (class.with || class.require > 0) && g.classAllowedAt(class.class, i, plan.Length) {
+				satisfiable = true
+				break
+			}
+		}
+		if !satisfiable {
+			return fmt.Errorf("%w: no enabled class may be placed at position %d", ErrUnsatisfiablePosition, i)
+		}
+	}
+	return nil
+}
+
+// validatePins checks the positions pinned by PinPosition are inside the password and don't
+// conflict with each other or the other positional constraints.
+func (g *Generator) validatePins(plan *GenerationPlan) error {
+	pinned := g.pinnedCounts()
+	exact := [4]bool{g.exactLower, g.exactUpper, g.exactDigits, g.exactSymbols}
+	exactCounts := [4]int{g.requireLower, g.requireUpper, g.requireDigits, g.requireSymbols}
+	for _, p := range g.pins {
+		if !p.class.valid() {
+			return fmt.Errorf("%w: position %d is pinned to an invalid class", ErrUnsatisfiablePosition, p.index)
+		}
+		if p.index < 0 || p.index >= plan.Length {
+			return fmt.Errorf("%w: pinned position %d is outside a password of length %d",
+				ErrUnsatisfiablePosition, p.index, plan.Length)
+		}
+		if class, _ := g.pinnedAt(p.index); class != p.class {
+			return fmt.Errorf("%w: position %d is pinned to both %s and %s", ErrUnsatisfiablePosition, p.index, class, p.class)
+		}
+		if !g.classAllowedAt(p.class, p.index, plan.Length) {
+			return fmt.Errorf("%w: position %d is pinned to a forbidden %s", ErrUnsatisfiablePosition, p.index, p.class)
+		}
+		if exact[p.class] && pinned[p.class] > exactCounts[p.class] {
+			return fmt.Errorf("%w: %d positions are pinned to %s but exactly %d are allowed",
+				ErrUnsatisfiablePosition, pinned[p.class], p.class, exactCounts[p.class])
+		}
+	}
+	return nil
+}
+
+// validatePlacement checks the positions reserved for each class fit in the positions of the
+// password the class may be placed at, e.g. three exact symbols don't fit a password of length
+// 4 whose first and last characters can't be symbols.  Every set of classes must be allowed at
+// least as many positions as are reserved for the set, so the reserved characters can all be
+// placed at once.
+func (g *Generator) validatePlacement(plan *GenerationPlan) error {
+	if !g.hasPositionalConstraints() {
+		return nil
+	}
+
+	// positions counts the positions by the mask of the classes which may be placed at them.
+	var positions [1 << 4]int
+	for i := 0; i < plan.Length; i++ {
+		pinned, isPinned := g.pinnedAt(i)
+		mask := 0
+		for class := ClassLower; class <= ClassSymbol; class++ {
+			if g.classAllowedAt(class, i, plan.Length) && (!isPinned || pinned == class) {
+				mask |= 1 << class
+			}
+		}
+		positions[mask]++
+	}
+
+	reserved := [4]int{plan.Lower, plan.Upper, plan.Digits, plan.Symbols}
+	for set := 1; set < len(positions); set++ {
+		required, allowed := 0, 0
+		var names []string
+		for class := ClassLower; class <= ClassSymbol; class++ {
+			if set&(1<<class) != 0 {
+				required += reserved[class]
+				names = append(names, class.String())
+			}
+		}
+		for mask, n := range positions {
+			if mask&set != 0 {
+				allowed += n
+			}
+		}
+		if required > allowed {
+			return fmt.Errorf("%w: %d %s characters are required but only %d positions allow them",
+				ErrUnsatisfiablePosition, required, strings.Join(names, " or "), allowed)
+		}
+	}
+	return nil
+}
+
+// validPositions returns whether the password satisfies every positional constraint.
+func (g *Generator) validPositions(elements []string) bool {
+	for _, i := range g.constrainedPositions(len(elements)) {
+		if !g.allowedAt(i, len(elements), elements[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// arrange swaps elements of the shuffled password so the positional constraints hold.  If no
+// element can be swapped in the constraint is left unsatisfied, which accepts rejects.
+func (g *Generator) arrange(elements []string) error {
+	length := len(elements)
+	for _, i := range g.constrainedPositions(length) {
+		if g.allowedAt(i, length, elements[i]) {
+			continue
+		}
+
+		// Only swap with elements which may be placed at i, and which don't break the
+		// constraint of their new position.
+		var candidates []int
+		for j, elm := range elements {
+			if j != i && g.allowedAt(i, length, elm) && g.allowedAt(j, length, elements[i]) {
+				candidates = append(candidates, j)
+			}
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+		n, err := rand.Int(g.reader(), big.NewInt(int64(len(candidates))))
+		if err != nil {
+			return err
+		}
+		swap := candidates[n.Int64()]
+		elements[i], elements[swap] = elements[swap], elements[i]
+	}
+	return nil
+}
+
+// isDigit returns whether the element is a digit in any script.
+func isDigit(elm string) bool {
+	r := []rune(elm)
+	return len(r) == 1 && unicode.IsDigit(r[0])
+}
+
+// isLetter returns whether the element is part of the generator's lower or upper case letters.
+func (g *Generator) isLetter(elm string) bool {
+	pools := g.pools()
+	return slices.Contains(pools.elements[ClassLower], elm) || slices.Contains(pools.elements[ClassUpper], elm)
+}
+
+// isSymbol returns whether the element is part of the generator's symbol set.
+func (g *Generator) isSymbol(elm string) bool {
+	return slices.Contains(g.pools().elements[ClassSymbol], elm)
+}
@@ -0,0 +1,303 @@
+package passwordgen
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestGenerator_ForbidLeadingDigit(t *testing.T) {
+	t.Parallel()
+
+	t.Run("never_leading", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().RequireDigits(5).ForbidLeadingDigit()
+		for i := 0; i < 200; i++ {
+			pass, err := gen.Generate(6)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if containsDigits.MatchString(pass[:1]) {
+				t.Errorf("password %s starts with a digit", pass)
+			}
+		}
+	})
+
+	t.Run("symbols_allowed", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithSymbols().RequireDigits(1).ForbidLeadingDigit()
+		for i := 0; i < 50; i++ {
+			pass, err := gen.Generate(2)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if !containsSymnbols.MatchString(pass[:1]) {
+				t.Errorf("Expected password %s to start with a symbol", pass)
+			}
+		}
+	})
+
+	t.Run("only_digits", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithDigits().ForbidLeadingDigit()
+		if _, err := gen.Generate(6); !errors.Is(err, ErrUnsatisfiablePosition) {
+			t.Errorf("expected: %q, actual: %q", ErrUnsatisfiablePosition, err)
+		}
+	})
+
+	t.Run("digits_exceed_positions", func(t *testing.T) {
+		t.Parallel()
+		for _, gen := range []*Generator{
+			NewGenerator().WithLower().RequireDigits(6).ForbidLeadingDigit(),
+			NewGenerator().WithSymbols().ExactDigits(4).ForbidLeadingDigit(),
+		} {
+			if _, err := gen.Generate(gen.requireDigits); !errors.Is(err, ErrUnsatisfiablePosition) {
+				t.Errorf("expected: %q, actual: %q", ErrUnsatisfiablePosition, err)
+			}
+		}
+	})
+}
+
+func TestGenerator_ForbidBoundarySymbols(t *testing.T) {
+	t.Parallel()
+
+	t.Run("never_at_boundary", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().RequireSymbols(4).ForbidBoundarySymbols()
+		symbols := split(Symbols)
+		for i := 0; i < 200; i++ {
+			pass, err := gen.Generate(6)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			elements := split(pass)
+			if slices.Contains(symbols, elements[0]) || slices.Contains(symbols, elements[len(elements)-1]) {
+				t.Errorf("password %s starts or ends with a symbol", pass)
+			}
+		}
+	})
+
+	t.Run("with_leading_digit", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithDigits().WithSymbols().RequireLower(1).ForbidBoundarySymbols().ForbidLeadingDigit()
+		for i := 0; i < 50; i++ {
+			pass, err := gen.Generate(8)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if !containsLower.MatchString(pass[:1]) {
+				t.Errorf("expected password %s to start with a lower case letter", pass)
+			}
+			if containsSymnbols.MatchString(pass[len(pass)-1:]) {
+				t.Errorf("password %s ends with a symbol", pass)
+			}
+		}
+	})
+
+	t.Run("only_symbols", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithSymbols().ForbidBoundarySymbols()
+		if _, err := gen.Generate(6); !errors.Is(err, ErrUnsatisfiablePosition) {
+			t.Errorf("expected: %q, actual: %q", ErrUnsatisfiablePosition, err)
+		}
+	})
+
+	t.Run("symbols_exceed_interior", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().ExactSymbols(5).ForbidBoundarySymbols()
+		if _, err := gen.Generate(6); !errors.Is(err, ErrUnsatisfiablePosition) {
+			t.Errorf("expected: %q, actual: %q", ErrUnsatisfiablePosition, err)
+		}
+	})
+
+	t.Run("symbols_exceed_interior_with_leading_digit", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().ExactDigits(2).ExactSymbols(2).ForbidBoundarySymbols().ForbidLeadingDigit()
+		if _, err := gen.Generate(4); !errors.Is(err, ErrUnsatisfiablePosition) {
+			t.Errorf("expected: %q, actual: %q", ErrUnsatisfiablePosition, err)
+		}
+	})
+}
+
+func TestGenerator_StartWithLetter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("always_leading", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithUpper().WithDigits().WithSymbols().RequireDigits(3).StartWithLetter().NoSymbolAtEnds()
+		for i := 0; i < 200; i++ {
+			pass, err := gen.Generate(6)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if !containsUpper.MatchString(pass[:1]) {
+				t.Errorf("password %s doesn't start with a letter", pass)
+			}
+			if containsSymnbols.MatchString(pass[len(pass)-1:]) {
+				t.Errorf("password %s ends with a symbol", pass)
+			}
+		}
+	})
+
+	t.Run("pinned_conflict", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithDigits().StartWithLetter().PinPosition(0, ClassDigit)
+		if _, err := gen.Generate(6); !errors.Is(err, ErrUnsatisfiablePosition) {
+			t.Errorf("expected: %q, actual: %q", ErrUnsatisfiablePosition, err)
+		}
+	})
+
+	t.Run("no_letters", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithDigits().WithSymbols().StartWithLetter()
+		if _, err := gen.Generate(6); !errors.Is(err, ErrUnsatisfiablePosition) {
+			t.Errorf("expected: %q, actual: %q", ErrUnsatisfiablePosition, err)
+		}
+	})
+
+	t.Run("reserved_exceed_positions", func(t *testing.T) {
+		t.Parallel()
+		for _, gen := range []*Generator{
+			NewGenerator().WithLower().RequireDigits(4).StartWithLetter(),
+			NewGenerator().WithLower().ExactDigits(2).ExactSymbols(2).StartWithLetter(),
+		} {
+			if _, err := gen.Generate(4); !errors.Is(err, ErrUnsatisfiablePosition) {
+				t.Errorf("expected: %q, actual: %q", ErrUnsatisfiablePosition, err)
+			}
+		}
+	})
+}
+
+func TestGenerator_PinPosition(t *testing.T) {
+	t.Parallel()
+
+	t.Run("pins_hold", func(t *testing.T) {
+		t.Parallel()
+		length := 12
+		gen := NewGenerator().WithLower().WithUpper().WithDigits().
+			PinPosition(0, ClassUpper).PinPosition(length-1, ClassDigit)
+		for i := 0; i < 200; i++ {
+			pass, err := gen.Generate(length)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if !containsUpper.MatchString(pass[:1]) {
+				t.Errorf("Expected password %s to start with an upper case letter", pass)
+			}
+			if !containsDigits.MatchString(pass[length-1:]) {
+				t.Errorf("Expected password %s to end with a digit", pass)
+			}
+		}
+	})
+
+	t.Run("pins_reserve_characters", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithDigits().
+			PinPosition(0, ClassDigit).PinPosition(1, ClassDigit).PinPosition(2, ClassDigit)
+		plan, err := gen.Plan(3)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if plan.Digits != 3 {
+			t.Errorf("expected: %d, actual: %d", 3, plan.Digits)
+		}
+		pass, err := gen.Generate(3)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if _, _, digits, _, _ := CountClasses(pass); digits != 3 {
+			t.Errorf("Expected password %s to be all digits", pass)
+		}
+	})
+
+	t.Run("with_forbid_rules", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithSymbols().ForbidBoundarySymbols().PinPosition(3, ClassSymbol)
+		symbols := split(Symbols)
+		for i := 0; i < 100; i++ {
+			pass, err := gen.Generate(8)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if !slices.Contains(symbols, pass[3:4]) {
+				t.Errorf("Expected password %s to have a symbol at position 3", pass)
+			}
+		}
+	})
+
+	t.Run("out_of_range", func(t *testing.T) {
+		t.Parallel()
+		for _, index := range []int{-1, 8} {
+			gen := NewGenerator().WithLower().WithUpper().PinPosition(index, ClassUpper)
+			if _, err := gen.Generate(8); !errors.Is(err, ErrUnsatisfiablePosition) {
+				t.Errorf("expected: %q, actual: %q", ErrUnsatisfiablePosition, err)
+			}
+		}
+	})
+
+	t.Run("conflicting_pins", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithUpper().PinPosition(2, ClassUpper).PinPosition(2, ClassLower)
+		if _, err := gen.Generate(8); !errors.Is(err, ErrUnsatisfiablePosition) {
+			t.Errorf("expected: %q, actual: %q", ErrUnsatisfiablePosition, err)
+		}
+	})
+
+	t.Run("conflicts_with_forbid", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithDigits().ForbidLeadingDigit().PinPosition(0, ClassDigit)
+		if _, err := gen.Generate(8); !errors.Is(err, ErrUnsatisfiablePosition) {
+			t.Errorf("expected: %q, actual: %q", ErrUnsatisfiablePosition, err)
+		}
+	})
+
+	t.Run("exceeds_exact", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().ExactDigits(1).PinPosition(0, ClassDigit).PinPosition(1, ClassDigit)
+		if _, err := gen.Generate(8); !errors.Is(err, ErrUnsatisfiablePosition) {
+			t.Errorf("expected: %q, actual: %q", ErrUnsatisfiablePosition, err)
+		}
+	})
+
+	t.Run("reserved_exceed_allowed_positions", func(t *testing.T) {
+		t.Parallel()
+		for name, tc := range map[string]struct {
+			gen    *Generator
+			length int
+		}{
+			"exact_symbols":   {NewGenerator().WithLower().ExactSymbols(3).ForbidBoundarySymbols(), 4},
+			"single_position": {NewGenerator().WithLower().RequireSymbols(1).ForbidBoundarySymbols(), 1},
+			"both_boundaries": {NewGenerator().WithLower().RequireSymbols(2).ForbidBoundarySymbols(), 2},
+			"pinned_elsewhere": {NewGenerator().WithLower().ExactDigits(2).ForbidLeadingDigit().
+				PinPosition(1, ClassLower), 3},
+		} {
+			_, err := tc.gen.Generate(tc.length)
+			if !errors.Is(err, ErrUnsatisfiablePosition) {
+				t.Errorf("expected: %q, actual: %q for %s", ErrUnsatisfiablePosition, err, name)
+			}
+		}
+	})
+
+	t.Run("reserved_fit_allowed_positions", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().ExactSymbols(2).ForbidBoundarySymbols()
+		for i := 0; i < 20; i++ {
+			pass, err := gen.Generate(4)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if !strings.ContainsAny(pass[1:2], Symbols) || !strings.ContainsAny(pass[2:3], Symbols) {
+				t.Errorf("expected the interior of %s to be symbols", pass)
+			}
+		}
+	})
+
+	t.Run("invalid_class", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().PinPosition(0, CharClass(9))
+		if _, err := gen.Generate(8); !errors.Is(err, ErrUnsatisfiablePosition) {
+			t.Errorf("expected: %q, actual: %q", ErrUnsatisfiablePosition, err)
+		}
+	})
+}
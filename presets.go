@@ -0,0 +1,28 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+// DefaultStrong returns a generator using lower and upper case letters, digits, and symbols,
+// with at least one character of each.
+func DefaultStrong() *Generator {
+	return NewGenerator().RequireLower(1).RequireUpper(1).RequireDigits(1).RequireSymbols(1)
+}
+
+// DefaultReadable returns a generator using lower and upper case letters and digits without
+// the ambiguous characters, for passwords which are read or typed by people.
+func DefaultReadable() *Generator {
+	return NewGenerator().NoAmbiguousCharacters().WithLower().WithUpper().WithDigits()
+}
+
+// DefaultPIN returns a generator using digits only.
+func DefaultPIN() *Generator {
+	return NewGenerator().WithDigits()
+}
@@ -0,0 +1,53 @@
+package passwordgen
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPresets(t *testing.T) {
+	t.Parallel()
+
+	t.Run("strong", func(t *testing.T) {
+		t.Parallel()
+		for i := 0; i < 50; i++ {
+			pass, err := DefaultStrong().Generate(16)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			lower, upper, digits, symbols, _ := CountClasses(pass)
+			if lower == 0 || upper == 0 || digits == 0 || symbols == 0 {
+				t.Errorf("expected password %s to contain all four classes", pass)
+			}
+		}
+	})
+
+	t.Run("readable", func(t *testing.T) {
+		t.Parallel()
+		pass, err := DefaultReadable().Generate(16)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if strings.Trim(pass, LowerLettersNoAmbig+UpperLettersNoAmbig+DigitsNoAmbig) != "" {
+			t.Errorf("password %s contains ambiguous characters or symbols", pass)
+		}
+	})
+
+	t.Run("pin", func(t *testing.T) {
+		t.Parallel()
+		pass, err := DefaultPIN().Generate(6)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if len(pass) != 6 || strings.Trim(pass, Digits) != "" {
+			t.Errorf("expected password %s to be 6 digits", pass)
+		}
+	})
+
+	t.Run("tweakable", func(t *testing.T) {
+		t.Parallel()
+		if DefaultPIN().WithLower().Equal(DefaultPIN()) {
+			t.Errorf("expected presets to return a new generator each call")
+		}
+	})
+}
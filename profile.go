@@ -0,0 +1,96 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrUnknownProfile is the error returned when a password is generated for a
+// profile which hasn't been registered
+var ErrUnknownProfile = errors.New("unknown profile")
+
+// ProfileRegistry is a set of named generators, e.g. one per password policy of a service.
+// It is safe for use by multiple goroutines.
+type ProfileRegistry struct {
+	mu       sync.RWMutex
+	profiles map[string]*Generator
+}
+
+// NewProfileRegistry returns an empty profile registry.
+func NewProfileRegistry() *ProfileRegistry {
+	return &ProfileRegistry{profiles: map[string]*Generator{}}
+}
+
+// defaultProfiles is the registry used by the package level profile functions.
+var defaultProfiles = NewProfileRegistry()
+
+// Register adds the generator under name, replacing any profile already registered with that
+// name.  The generator is cloned, changes made to it afterwards do not affect the profile.
+func (r *ProfileRegistry) Register(name string, g *Generator) {
+	clone := g.Clone()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.profiles[name] = clone
+}
+
+// Generate will generate a password at the specified length with the profile registered under
+// name, or return ErrUnknownProfile.
+func (r *ProfileRegistry) Generate(name string, length int) (string, error) {
+	r.mu.RLock()
+	g, ok := r.profiles[name]
+	r.mu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownProfile, name)
+	}
+	return g.Generate(length)
+}
+
+// GenerateAll will generate one password at the specified length for every registered profile,
+// keyed by profile name.  If any profile fails no passwords are returned.
+func (r *ProfileRegistry) GenerateAll(length int) (map[string]string, error) {
+	r.mu.RLock()
+	profiles := make(map[string]*Generator, len(r.profiles))
+	for name, g := range r.profiles {
+		profiles[name] = g
+	}
+	r.mu.RUnlock()
+
+	passwords := make(map[string]string, len(profiles))
+	for name, g := range profiles {
+		pass, err := g.Generate(length)
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: %w", name, err)
+		}
+		passwords[name] = pass
+	}
+	return passwords, nil
+}
+
+// RegisterProfile adds the generator to the default registry under name, see
+// ProfileRegistry.Register.
+func RegisterProfile(name string, g *Generator) {
+	defaultProfiles.Register(name, g)
+}
+
+// GenerateProfile will generate a password at the specified length with the profile registered
+// under name in the default registry.
+func GenerateProfile(name string, length int) (string, error) {
+	return defaultProfiles.Generate(name, length)
+}
+
+// GenerateAll will generate one password at the specified length for every profile in the
+// default registry.
+func GenerateAll(length int) (map[string]string, error) {
+	return defaultProfiles.GenerateAll(length)
+}
@@ -0,0 +1,104 @@
+package passwordgen
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestProfileRegistry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("generate", func(t *testing.T) {
+		t.Parallel()
+		registry := NewProfileRegistry()
+		registry.Register("pin", NewGenerator().WithDigits())
+		pass, err := registry.Generate("pin", 6)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if strings.Trim(pass, Digits) != "" || len(pass) != 6 {
+			t.Errorf("expected password %s to be 6 digits", pass)
+		}
+	})
+
+	t.Run("cloned", func(t *testing.T) {
+		t.Parallel()
+		registry := NewProfileRegistry()
+		gen := NewGenerator().WithDigits()
+		registry.Register("pin", gen)
+		gen.WithLower()
+		pass, err := registry.Generate("pin", 32)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if strings.Trim(pass, Digits) != "" {
+			t.Errorf("expected password %s to only contain digits", pass)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		t.Parallel()
+		if _, err := NewProfileRegistry().Generate("web", 16); !errors.Is(err, ErrUnknownProfile) {
+			t.Errorf("expected: %q, actual: %q", ErrUnknownProfile, err)
+		}
+	})
+
+	t.Run("generate_all", func(t *testing.T) {
+		t.Parallel()
+		registry := NewProfileRegistry()
+		registry.Register("web", NewGenerator().WithLower().WithUpper().RequireDigits(1))
+		registry.Register("db", NewGenerator().WithLower().WithDigits())
+		registry.Register("api", NewGenerator().WithUpper().WithDigits())
+		passwords, err := registry.GenerateAll(16)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if len(passwords) != 3 {
+			t.Errorf("expected: 3 passwords, actual: %d", len(passwords))
+		}
+		for _, name := range []string{"web", "db", "api"} {
+			if len(passwords[name]) != 16 {
+				t.Errorf("expected a 16 character password for %s, received %q", name, passwords[name])
+			}
+		}
+
+		registry.Register("bad", NewGenerator())
+		if _, err := registry.GenerateAll(16); !errors.Is(err, ErrNoCharactersSpecified) {
+			t.Errorf("expected: %q, actual: %q", ErrNoCharactersSpecified, err)
+		}
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		t.Parallel()
+		registry := NewProfileRegistry()
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				registry.Register("web", NewGenerator().WithLower())
+				if _, err := registry.GenerateAll(8); err != nil {
+					t.Errorf("expected no error, received %q", err)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("default_registry", func(t *testing.T) {
+		t.Parallel()
+		RegisterProfile("profile_test", NewGenerator().WithLower())
+		if _, err := GenerateProfile("profile_test", 8); err != nil {
+			t.Errorf("expected no error, received %q", err)
+		}
+		passwords, err := GenerateAll(8)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if _, ok := passwords["profile_test"]; !ok {
+			t.Errorf("expected a password for profile_test")
+		}
+	})
+}
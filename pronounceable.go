@@ -0,0 +1,99 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+// Vowels is the list of lower case vowels used by GeneratePronounceable, every other lower
+// case letter is treated as a consonant.
+const Vowels = "aeiou"
+
+// ErrInvalidSyllableCount is the error returned when a pronounceable password
+// is requested with a non-positive number of syllables
+var ErrInvalidSyllableCount = errors.New("number of syllables must be positive")
+
+// GeneratePronounceable will generate a pronounceable password made of the given number of
+// consonant-vowel syllables, e.g. bakolu.  Letters are drawn from the generator's lower case
+// letters, so NoAmbiguousCharacters is respected.  If upper case letters are enabled the first
+// letter is capitalized, and if RequireDigits is set that many digits are appended.
+//
+// Pronounceable passwords have far less entropy per character than a password drawn from the
+// full pool, see PronounceableEntropy.
+func (g *Generator) GeneratePronounceable(syllables int) (string, error) {
+	if syllables <= 0 {
+		return "", ErrInvalidSyllableCount
+	}
+
+	consonants, vowels := g.pronounceableLetters()
+	if len(consonants) == 0 || len(vowels) == 0 {
+		return "", ErrEmptyCharacterSet
+	}
+
+	buffer := strings.Builder{}
+	for i := 0; i < syllables; i++ {
+		for _, letters := range [][]string{consonants, vowels} {
+			elm, err := g.randomElement(letters)
+			if err != nil {
+				return "", err
+			}
+			if buffer.Len() == 0 && (g.withUpper || g.requireUpper > 0) {
+				elm = strings.ToUpper(elm)
+			}
+			buffer.WriteString(elm)
+		}
+	}
+
+	if g.requireDigits > 0 {
+		digits := split(g.digits)
+		if len(digits) == 0 {
+			return "", ErrEmptyCharacterSet
+		}
+		for i := 0; i < g.requireDigits; i++ {
+			elm, err := g.randomElement(digits)
+			if err != nil {
+				return "", err
+			}
+			buffer.WriteString(elm)
+		}
+	}
+
+	return buffer.String(), nil
+}
+
+// PronounceableEntropy returns the entropy in bits of a password returned by
+// GeneratePronounceable with the given number of syllables.
+func (g *Generator) PronounceableEntropy(syllables int) float64 {
+	consonants, vowels := g.pronounceableLetters()
+	if syllables <= 0 || len(consonants) == 0 || len(vowels) == 0 {
+		return 0
+	}
+	bits := float64(syllables) * math.Log2(float64(len(consonants)*len(vowels)))
+	if digits := len(split(g.digits)); g.requireDigits > 0 && digits > 0 {
+		bits += float64(g.requireDigits) * math.Log2(float64(digits))
+	}
+	return bits
+}
+
+// pronounceableLetters splits the lower case letters of the generator into consonants and vowels.
+func (g *Generator) pronounceableLetters() (consonants, vowels []string) {
+	for _, elm := range split(g.lowerLetters) {
+		if strings.Contains(Vowels, elm) {
+			vowels = append(vowels, elm)
+		} else {
+			consonants = append(consonants, elm)
+		}
+	}
+	return consonants, vowels
+}
@@ -0,0 +1,65 @@
+package passwordgen
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestGenerator_GeneratePronounceable(t *testing.T) {
+	t.Parallel()
+
+	t.Run("alternates", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator()
+		for i := 0; i < 20; i++ {
+			pass, err := gen.GeneratePronounceable(4)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if len(pass) != 8 {
+				t.Errorf("Expected password %s to be 8 characters long", pass)
+			}
+			for i, r := range pass {
+				if isVowel := strings.ContainsRune(Vowels, r); isVowel != (i%2 == 1) {
+					t.Errorf("password %s does not alternate consonants and vowels at position %d", pass, i)
+				}
+			}
+		}
+	})
+
+	t.Run("capitalized_with_digits", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithUpper().RequireDigits(2)
+		pass, err := gen.GeneratePronounceable(3)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if !strings.ContainsRune(UpperLetters, rune(pass[0])) {
+			t.Errorf("Expected password %s to start with an upper case letter", pass)
+		}
+		if !strings.ContainsRune(Digits, rune(pass[6])) || !strings.ContainsRune(Digits, rune(pass[7])) {
+			t.Errorf("Expected password %s to end with 2 digits", pass)
+		}
+	})
+
+	t.Run("invalid_syllables", func(t *testing.T) {
+		t.Parallel()
+		if _, err := NewGenerator().GeneratePronounceable(0); err != ErrInvalidSyllableCount {
+			t.Errorf("expected: %q, actual: %q", ErrInvalidSyllableCount, err)
+		}
+	})
+
+	t.Run("entropy", func(t *testing.T) {
+		t.Parallel()
+		// 21 consonants and 5 vowels per syllable, plus 2 digits.
+		expected := 4*math.Log2(21*5) + 2*math.Log2(10)
+		if bits := NewGenerator().RequireDigits(2).PronounceableEntropy(4); math.Abs(bits-expected) > 1e-9 {
+			t.Errorf("expected: %f, actual: %f", expected, bits)
+		}
+		// Pronounceable passwords are weaker than random lower case letters of the same length.
+		if bits := NewGenerator().PronounceableEntropy(4); bits >= 8*math.Log2(26) {
+			t.Errorf("expected less than %f bits, actual: %f", 8*math.Log2(26), bits)
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package passwordgen
+
+import (
+	"errors"
+	mrand "math/rand/v2"
+	"reflect"
+	"testing"
+)
+
+func TestGenerator_WithRand(t *testing.T) {
+	t.Parallel()
+
+	// generate generates a batch of passwords with a reader seeded with seed.
+	generate := func(t *testing.T, seed byte) []string {
+		gen := NewGenerator().WithLower().WithUpper().RequireDigits(2).RequireSymbols(2).
+			RequireClassDiversity(4).ForbidLeadingDigit().WithRand(mrand.NewChaCha8([32]byte{seed}))
+		var passwords []string
+		for i := 0; i < 20; i++ {
+			pass, err := gen.GenerateGrouped(4, 4, "-")
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			passwords = append(passwords, pass)
+		}
+		return passwords
+	}
+
+	t.Run("reproducible", func(t *testing.T) {
+		t.Parallel()
+		first, second := generate(t, 1), generate(t, 1)
+		if !reflect.DeepEqual(first, second) {
+			t.Errorf("expected identical passwords from identical readers, received %q and %q", first, second)
+		}
+		if other := generate(t, 2); reflect.DeepEqual(first, other) {
+			t.Errorf("expected different passwords from differently seeded readers")
+		}
+	})
+
+	t.Run("reader_error", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithRand(failingReader{})
+		if _, err := gen.Generate(8); !errors.Is(err, errFailingReader) {
+			t.Errorf("expected: %q, actual: %q", errFailingReader, err)
+		}
+	})
+}
+
+func TestPassphraseGenerator_WithRand(t *testing.T) {
+	t.Parallel()
+
+	// generate generates a batch of passphrases with a reader seeded with seed.
+	generate := func(t *testing.T, seed byte) []string {
+		gen := NewPassphraseGenerator().WithSymbolSeparator().WithRand(mrand.NewChaCha8([32]byte{seed}))
+		var phrases []string
+		for i := 0; i < 20; i++ {
+			phrase, err := gen.Generate(4)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			phrases = append(phrases, phrase)
+		}
+		return phrases
+	}
+
+	t.Run("reproducible", func(t *testing.T) {
+		t.Parallel()
+		first, second := generate(t, 1), generate(t, 1)
+		if !reflect.DeepEqual(first, second) {
+			t.Errorf("expected identical passphrases from identical readers, received %q and %q", first, second)
+		}
+		if other := generate(t, 2); reflect.DeepEqual(first, other) {
+			t.Errorf("expected different passphrases from differently seeded readers")
+		}
+	})
+
+	t.Run("reader_error", func(t *testing.T) {
+		t.Parallel()
+		gen := NewPassphraseGenerator().WithRand(failingReader{})
+		if _, err := gen.Generate(4); !errors.Is(err, errFailingReader) {
+			t.Errorf("expected: %q, actual: %q", errFailingReader, err)
+		}
+	})
+}
+
+var errFailingReader = errors.New("reader failed")
+
+// failingReader is a source of randomness which always fails.
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errFailingReader
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import (
+	"errors"
+	"strings"
+)
+
+// DefaultRecoveryCodeFormat is the format of recovery codes used by GenerateRecoveryCodes when
+// the format is empty, 12 characters in groups of 4, e.g. 7KQ2-M9XD-4HTR.
+const DefaultRecoveryCodeFormat = "XXXX-XXXX-XXXX"
+
+// ErrInvalidFormat is the error returned when a recovery code format has no
+// placeholder to fill
+var ErrInvalidFormat = errors.New("format must contain at least one X placeholder")
+
+// GenerateRecoveryCodes will generate count distinct recovery codes, such as 2FA backup codes,
+// from upper case letters and digits without the ambiguous ones.  See
+// Generator.GenerateRecoveryCodes for the format.
+func GenerateRecoveryCodes(count int, format string) ([]string, error) {
+	return NewGenerator().WithUpper().WithDigits().NoAmbiguousCharacters().GenerateRecoveryCodes(count, format)
+}
+
+// GenerateRecoveryCodes will generate count distinct recovery codes as configured.  Every X of
+// format is replaced by a generated character and every other character is kept, so
+// "XXXX-XXXX" gives codes like 7KQ2-M9XD.  The requirements of the generator apply to the
+// generated characters of each code.  An empty format is DefaultRecoveryCodeFormat.
+func (g *Generator) GenerateRecoveryCodes(count int, format string) ([]string, error) {
+	if format == "" {
+		format = DefaultRecoveryCodeFormat
+	}
+	length := strings.Count(format, "X")
+	if length == 0 {
+		return nil, ErrInvalidFormat
+	}
+
+	// The literal characters are the same in every code, so codes are distinct exactly when
+	// their generated characters are.
+	codes, err := g.GenerateUniqueN(count, length)
+	if err != nil {
+		return nil, err
+	}
+	for i, code := range codes {
+		codes[i] = applyFormat(format, split(code))
+	}
+	return codes, nil
+}
+
+// applyFormat returns format with its placeholders replaced by elements in order.
+func applyFormat(format string, elements []string) string {
+	buffer := strings.Builder{}
+	for _, r := range format {
+		if r == 'X' {
+			buffer.WriteString(elements[0])
+			elements = elements[1:]
+			continue
+		}
+		buffer.WriteRune(r)
+	}
+	return buffer.String()
+}
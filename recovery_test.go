@@ -0,0 +1,64 @@
+package passwordgen
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+)
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default_format", func(t *testing.T) {
+		t.Parallel()
+		codes, err := GenerateRecoveryCodes(10, "")
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if len(codes) != 10 {
+			t.Fatalf("expected: %d, actual: %d", 10, len(codes))
+		}
+		format := regexp.MustCompile("^[" + UpperLettersNoAmbig + DigitsNoAmbig + "]{4}(-[" + UpperLettersNoAmbig + DigitsNoAmbig + "]{4}){2}$")
+		seen := map[string]bool{}
+		for _, code := range codes {
+			if !format.MatchString(code) {
+				t.Errorf("code %s does not match the default format", code)
+			}
+			if seen[code] {
+				t.Errorf("code %s is duplicated", code)
+			}
+			seen[code] = true
+		}
+	})
+
+	t.Run("custom_format", func(t *testing.T) {
+		t.Parallel()
+		codes, err := NewGenerator().WithDigits().GenerateRecoveryCodes(5, "XXX XXX")
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		for _, code := range codes {
+			if !regexp.MustCompile("^[0-9]{3} [0-9]{3}$").MatchString(code) {
+				t.Errorf("code %s does not match the format", code)
+			}
+		}
+	})
+
+	t.Run("insufficient_unique", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewGenerator().WithDigits().WithMaxAttempts(1000).GenerateRecoveryCodes(11, "X")
+		if !errors.Is(err, ErrInsufficientUnique) {
+			t.Errorf("expected: %q, actual: %q", ErrInsufficientUnique, err)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+		if _, err := GenerateRecoveryCodes(5, "----"); err != ErrInvalidFormat {
+			t.Errorf("expected: %q, actual: %q", ErrInvalidFormat, err)
+		}
+		if _, err := GenerateRecoveryCodes(0, ""); err != ErrInvalidCount {
+			t.Errorf("expected: %q, actual: %q", ErrInvalidCount, err)
+		}
+	})
+}
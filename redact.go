@@ -0,0 +1,56 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import "log/slog"
+
+// redacted is what a Password prints as.
+const redacted = "[REDACTED]"
+
+// Password is a generated password which prints as [REDACTED], so logging it by mistake with
+// %v, %s, or %#v, encoding it as JSON or text, or logging it with log/slog doesn't leak it.  Use
+// Reveal to access the password.
+type Password string
+
+// String returns [REDACTED].
+func (p Password) String() string {
+	return redacted
+}
+
+// GoString returns [REDACTED].
+func (p Password) GoString() string {
+	return redacted
+}
+
+// MarshalText returns [REDACTED], so encoding/json and the log/slog handlers redact the password.
+func (p Password) MarshalText() ([]byte, error) {
+	return []byte(redacted), nil
+}
+
+// LogValue returns [REDACTED] as the value of the password in log/slog records.
+func (p Password) LogValue() slog.Value {
+	return slog.StringValue(redacted)
+}
+
+// Reveal returns the password.
+func (p Password) Reveal() string {
+	return string(p)
+}
+
+// GenerateRedactable will generate a password at the specified length as configured, returned
+// as a Password which is redacted when printed.
+func (g *Generator) GenerateRedactable(length int) (Password, error) {
+	pass, err := g.Generate(length)
+	if err != nil {
+		return "", err
+	}
+	return Password(pass), nil
+}
@@ -0,0 +1,43 @@
+package passwordgen
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestGenerator_GenerateRedactable(t *testing.T) {
+	t.Parallel()
+
+	t.Run("redacted", func(t *testing.T) {
+		t.Parallel()
+		pw, err := NewGenerator().WithLower().WithDigits().GenerateRedactable(16)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		for _, format := range []string{"%s", "%v", "%#v", "%+v"} {
+			if actual := fmt.Sprintf(format, pw); actual != "[REDACTED]" {
+				t.Errorf("expected: %q, actual: %q for %s", "[REDACTED]", actual, format)
+			}
+		}
+		if actual := fmt.Sprintf("%v", struct{ Password Password }{pw}); actual != "{[REDACTED]}" {
+			t.Errorf("expected: %q, actual: %q", "{[REDACTED]}", actual)
+		}
+		if text, err := pw.MarshalText(); err != nil || string(text) != "[REDACTED]" {
+			t.Errorf("expected: %q, actual: %q", "[REDACTED]", text)
+		}
+		if value := pw.LogValue(); value.String() != "[REDACTED]" {
+			t.Errorf("expected: %q, actual: %q", "[REDACTED]", value)
+		}
+		if len(pw.Reveal()) != 16 || pw.Reveal() == "[REDACTED]" {
+			t.Errorf("expected Reveal to return the 16 character password, received %q", pw.Reveal())
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		if _, err := NewGenerator().GenerateRedactable(16); !errors.Is(err, ErrNoCharactersSpecified) {
+			t.Errorf("expected: %q, actual: %q", ErrNoCharactersSpecified, err)
+		}
+	})
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import "strings"
+
+// Result is a generated password with metadata about how it was generated, for auditing and
+// logging.  The password prints as [REDACTED], so a Result can be logged as is.
+type Result struct {
+	// Password is the generated password.
+	Password Password
+
+	// Length is the number of characters of the password.
+	Length int
+
+	// Entropy is the entropy in bits of the password, see Generator.Entropy.
+	Entropy float64
+
+	// Lower, Upper, Digits, and Symbols are the number of characters of each class in the
+	// password, and Other the characters of no class, such as those added with AddCharset.
+	Lower   int
+	Upper   int
+	Digits  int
+	Symbols int
+	Other   int
+
+	// PoolSize is the number of distinct characters the password was drawn from, see
+	// Generator.PoolSize.
+	PoolSize int
+
+	// Config is the configuration of the generator which generated the password.
+	Config Config
+}
+
+// GenerateDetailed will generate a password at the specified length as configured, along with
+// metadata about it.
+func (g *Generator) GenerateDetailed(length int) (*Result, error) {
+	elements, err := g.generate(length)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := g.countElements(elements)
+	return &Result{
+		Password: Password(strings.Join(elements, "")),
+		Length:   len(elements),
+		Entropy:  g.Entropy(len(elements)),
+		Lower:    counts[ClassLower],
+		Upper:    counts[ClassUpper],
+		Digits:   counts[ClassDigit],
+		Symbols:  counts[ClassSymbol],
+		Other:    len(elements) - counts[ClassLower] - counts[ClassUpper] - counts[ClassDigit] - counts[ClassSymbol],
+		PoolSize: g.PoolSize(),
+		Config:   g.ToConfig(),
+	}, nil
+}
@@ -0,0 +1,77 @@
+package passwordgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGenerator_GenerateDetailed(t *testing.T) {
+	t.Parallel()
+
+	t.Run("metadata", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().ExactUpper(2).ExactDigits(3).ExactSymbols(1).AddCharset("greek", "αβγ").RequireCharset("greek", 1)
+		result, err := gen.GenerateDetailed(12)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		pass := result.Password.Reveal()
+		if n := len([]rune(pass)); n != 12 || result.Length != 12 {
+			t.Errorf("expected: %d, actual: %d (%d)", 12, n, result.Length)
+		}
+		if result.Upper != 2 || result.Digits != 3 || result.Symbols != 1 || result.Other < 1 {
+			t.Errorf("unexpected counts %+v for password %s", result, pass)
+		}
+		if result.Lower+result.Upper+result.Digits+result.Symbols+result.Other != 12 {
+			t.Errorf("counts of %+v don't add up to the length", result)
+		}
+		if result.Entropy != gen.Entropy(12) {
+			t.Errorf("expected: %f, actual: %f", gen.Entropy(12), result.Entropy)
+		}
+		if result.PoolSize != gen.PoolSize() {
+			t.Errorf("expected: %d, actual: %d", gen.PoolSize(), result.PoolSize)
+		}
+		if !reflect.DeepEqual(result.Config, gen.ToConfig()) {
+			t.Errorf("expected: %+v, actual: %+v", gen.ToConfig(), result.Config)
+		}
+	})
+
+	t.Run("redacted", func(t *testing.T) {
+		t.Parallel()
+		result, err := NewGenerator().WithLower().GenerateDetailed(16)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		for _, format := range []string{"%v", "%+v", "%#v"} {
+			if printed := fmt.Sprintf(format, result); strings.Contains(printed, result.Password.Reveal()) {
+				t.Errorf("%s printed the password: %s", format, printed)
+			}
+		}
+
+		encoded, err := json.Marshal(result)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		var logged bytes.Buffer
+		slog.New(slog.NewJSONHandler(&logged, nil)).Info("generated", "result", result)
+		slog.New(slog.NewTextHandler(&logged, nil)).Info("generated", "result", result)
+		for name, out := range map[string]string{"json": string(encoded), "slog": logged.String()} {
+			if strings.Contains(out, result.Password.Reveal()) || !strings.Contains(out, "[REDACTED]") {
+				t.Errorf("%s encoded the password: %s", name, out)
+			}
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+		if _, err := NewGenerator().GenerateDetailed(8); !errors.Is(err, ErrNoCharactersSpecified) {
+			t.Errorf("expected: %q, actual: %q", ErrNoCharactersSpecified, err)
+		}
+	})
+}
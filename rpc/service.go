@@ -0,0 +1,228 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+// Package rpc implements the PasswordGenerator service of passwordgen.proto, so services
+// written in other languages can generate and validate passwords over the network.
+//
+// Service is independent of the transport.  Its request and response types mirror the
+// messages of passwordgen.proto, a gRPC server generated from the definition with protoc-gen-go
+// and protoc-gen-go-grpc wraps it by converting the generated messages, e.g.
+//
+//	func (s *grpcServer) GeneratePassword(ctx context.Context, req *passwordgenpb.GeneratePasswordRequest) (*passwordgenpb.GeneratePasswordResponse, error) {
+//		resp, err := s.service.GeneratePassword(ctx, &rpc.GeneratePasswordRequest{...})
+//		...
+//	}
+//
+// and mapping ErrInvalidArgument to codes.InvalidArgument.
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kenXengineering/passwordgen"
+	"github.com/kenXengineering/passwordgen/internal/limits"
+	"github.com/kenXengineering/passwordgen/strength"
+)
+
+// DefaultMaxCount is the most secrets generated by a single request, unless set in the
+// Service.
+const DefaultMaxCount = 100
+
+// DefaultMaxLength is the most characters of a password generated by the service, unless set
+// in the Service.  It also caps the maximum length of every policy, so a request can't lift
+// the limit of passwordgen.Generator.WithMaxLength.
+const DefaultMaxLength = 1024
+
+// DefaultMaxWords is the most words of a passphrase generated by the service, unless set in
+// the Service.
+const DefaultMaxWords = 64
+
+// MaxSeparatorLength is the most characters of the separator of a passphrase request.
+const MaxSeparatorLength = limits.MaxSeparatorLength
+
+// ErrInvalidArgument is the error returned when a request can't be served as it is, e.g. a
+// policy whose requirements don't fit its length
+var ErrInvalidArgument = errors.New("invalid argument")
+
+// GeneratePasswordRequest is the request of GeneratePassword.
+type GeneratePasswordRequest struct {
+	Policy passwordgen.Policy
+
+	// Length is the number of characters, derived from the policy if 0.
+	Length int
+
+	// Count is the number of passwords, 1 if 0.
+	Count int
+}
+
+// GeneratePasswordResponse is the response of GeneratePassword.
+type GeneratePasswordResponse struct {
+	Passwords []string
+}
+
+// GeneratePassphraseRequest is the request of GeneratePassphrase.
+type GeneratePassphraseRequest struct {
+	Words int
+
+	// Separator is placed between the words, passwordgen.DefaultPassphraseSeparator if empty.
+	// It is at most MaxSeparatorLength characters.
+	Separator string
+
+	Capitalization passwordgen.Capitalization
+
+	// Count is the number of passphrases, 1 if 0.
+	Count int
+}
+
+// GeneratePassphraseResponse is the response of GeneratePassphrase.
+type GeneratePassphraseResponse struct {
+	Passphrases []string
+}
+
+// ValidatePasswordRequest is the request of ValidatePassword.
+type ValidatePasswordRequest struct {
+	Policy   passwordgen.Policy
+	Password string
+
+	// UserInputs are strings such as the user name which make the password easier to guess.
+	UserInputs []string
+}
+
+// ValidatePasswordResponse is the response of ValidatePassword.
+type ValidatePasswordResponse struct {
+	// Valid is whether the password satisfies the policy, see Violation otherwise.
+	Valid     bool
+	Violation string
+
+	// Score and GuessesLog10 are the strength of the password, see strength.Estimate.
+	Score        int
+	GuessesLog10 float64
+}
+
+// Service implements the PasswordGenerator service.  A Service is safe for concurrent use.
+type Service struct {
+	// MaxCount is the most secrets generated by a single request.  If 0, DefaultMaxCount is
+	// used.
+	MaxCount int
+
+	// MaxLength is the most characters of a password.  If 0, DefaultMaxLength is used.
+	MaxLength int
+
+	// MaxWords is the most words of a passphrase.  If 0, DefaultMaxWords is used.
+	MaxWords int
+}
+
+// New returns a Service with the default limits.
+func New() *Service {
+	return &Service{}
+}
+
+// GeneratePassword generates passwords satisfying the policy of the request.
+func (s *Service) GeneratePassword(ctx context.Context, req *GeneratePasswordRequest) (*GeneratePasswordResponse, error) {
+	count, err := s.count(req.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	maxLength := limit(s.MaxLength, DefaultMaxLength)
+	if err := limits.Password(req.Policy, req.Length, maxLength); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArgument, err)
+	}
+	if req.Policy.MaxLength > 0 {
+		maxLength = min(maxLength, req.Policy.MaxLength)
+	}
+
+	gen := req.Policy.Generator().WithMaxLength(maxLength)
+	passwords := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var password string
+		if req.Length > 0 {
+			password, err = gen.Generate(req.Length)
+		} else {
+			password, err = gen.GenerateDefault()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidArgument, err)
+		}
+		passwords = append(passwords, password)
+	}
+	return &GeneratePasswordResponse{Passwords: passwords}, nil
+}
+
+// GeneratePassphrase generates passphrases drawn from the default wordlist.
+func (s *Service) GeneratePassphrase(ctx context.Context, req *GeneratePassphraseRequest) (*GeneratePassphraseResponse, error) {
+	count, err := s.count(req.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := limits.Passphrase(req.Words, req.Separator, limit(s.MaxWords, DefaultMaxWords)); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArgument, err)
+	}
+
+	gen := passwordgen.NewPassphraseGenerator().Capitalize(req.Capitalization)
+	if req.Separator != "" {
+		gen.WithSeparator(req.Separator)
+	}
+	passphrases := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		passphrase, err := gen.Generate(req.Words)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidArgument, err)
+		}
+		passphrases = append(passphrases, passphrase)
+	}
+	return &GeneratePassphraseResponse{Passphrases: passphrases}, nil
+}
+
+// ValidatePassword checks the password of the request against its policy and estimates its
+// strength.  A password which violates the policy isn't an error, the response reports it.
+func (s *Service) ValidatePassword(ctx context.Context, req *ValidatePasswordRequest) (*ValidatePasswordResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	resp := &ValidatePasswordResponse{Valid: true}
+	if err := req.Policy.Validate(req.Password); err != nil {
+		resp.Valid = false
+		resp.Violation = err.Error()
+	}
+	result := strength.Estimate(req.Password, req.UserInputs...)
+	resp.Score = result.Score
+	resp.GuessesLog10 = result.GuessesLog10
+	return resp, nil
+}
+
+// count returns the number of secrets of a request, or an error wrapping ErrInvalidArgument.
+func (s *Service) count(count int) (int, error) {
+	if count == 0 {
+		return 1, nil
+	}
+	maxCount := limit(s.MaxCount, DefaultMaxCount)
+	if count < 0 || count > maxCount {
+		return 0, fmt.Errorf("%w: %d secrets, between 1 and %d are allowed", ErrInvalidArgument, count, maxCount)
+	}
+	return count, nil
+}
+
+// limit returns the limit set in the Service, or def if it isn't set.
+func limit(set, def int) int {
+	if set <= 0 {
+		return def
+	}
+	return set
+}
@@ -0,0 +1,151 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/kenXengineering/passwordgen"
+)
+
+func TestService_GeneratePassword(t *testing.T) {
+	t.Parallel()
+
+	t.Run("policy", func(t *testing.T) {
+		t.Parallel()
+
+		req := &GeneratePasswordRequest{Policy: passwordgen.Policy{MinLength: 20, MinSymbols: 3}, Count: 4}
+		resp, err := New().GeneratePassword(context.Background(), req)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if len(resp.Passwords) != 4 {
+			t.Fatalf("expected: %d, actual: %d", 4, len(resp.Passwords))
+		}
+		for _, password := range resp.Passwords {
+			if err := req.Policy.Validate(password); err != nil {
+				t.Errorf("expected no error, received %q", err)
+			}
+		}
+	})
+
+	t.Run("invalid_arguments", func(t *testing.T) {
+		t.Parallel()
+
+		for _, req := range []*GeneratePasswordRequest{
+			{Policy: passwordgen.Policy{MaxLength: 4, MinDigits: 5}},
+			{Count: -1},
+			{Count: DefaultMaxCount + 1},
+			{Length: DefaultMaxLength + 1},
+			{Policy: passwordgen.Policy{MinLength: 10000000, MaxLength: 10000000}, Count: DefaultMaxCount},
+			{Policy: passwordgen.Policy{MinLower: math.MaxInt/4 + 1, MinUpper: math.MaxInt/4 + 1, MinDigits: math.MaxInt/4 + 1, MinSymbols: math.MaxInt/4 + 1}},
+		} {
+			_, err := New().GeneratePassword(context.Background(), req)
+			if !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("expected: %q, actual: %q", ErrInvalidArgument, err)
+			}
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := New().GeneratePassword(ctx, &GeneratePasswordRequest{})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected: %q, actual: %q", context.Canceled, err)
+		}
+	})
+}
+
+func TestService_GeneratePassphrase(t *testing.T) {
+	t.Parallel()
+
+	t.Run("options", func(t *testing.T) {
+		t.Parallel()
+
+		req := &GeneratePassphraseRequest{Words: 3, Separator: ".", Capitalization: passwordgen.CapitalizeTitle, Count: 2}
+		resp, err := New().GeneratePassphrase(context.Background(), req)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if len(resp.Passphrases) != 2 {
+			t.Fatalf("expected: %d, actual: %d", 2, len(resp.Passphrases))
+		}
+		for _, passphrase := range resp.Passphrases {
+			words := strings.Split(passphrase, ".")
+			if len(words) != 3 {
+				t.Errorf("expected: %d, actual: %d", 3, len(words))
+			}
+			for _, word := range words {
+				if word == "" || !unicode.IsUpper([]rune(word)[0]) {
+					t.Errorf("expected capitalized words, received %q", passphrase)
+				}
+			}
+		}
+	})
+
+	t.Run("too_many_words", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New().GeneratePassphrase(context.Background(), &GeneratePassphraseRequest{Words: DefaultMaxWords + 1})
+		if !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("expected: %q, actual: %q", ErrInvalidArgument, err)
+		}
+	})
+
+	t.Run("long_separator", func(t *testing.T) {
+		t.Parallel()
+
+		req := &GeneratePassphraseRequest{Words: DefaultMaxWords, Separator: strings.Repeat("-", MaxSeparatorLength+1), Count: DefaultMaxCount}
+		_, err := New().GeneratePassphrase(context.Background(), req)
+		if !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("expected: %q, actual: %q", ErrInvalidArgument, err)
+		}
+	})
+
+	t.Run("no_words", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := New().GeneratePassphrase(context.Background(), &GeneratePassphraseRequest{})
+		if !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("expected: %q, actual: %q", ErrInvalidArgument, err)
+		}
+	})
+}
+
+func TestService_ValidatePassword(t *testing.T) {
+	t.Parallel()
+
+	policy := passwordgen.Policy{MinLength: 12, MinDigits: 1}
+	for name, tc := range map[string]struct {
+		password string
+		valid    bool
+	}{
+		"valid":     {password: "vK7#qLm2!xRz9p", valid: true},
+		"too_short": {password: "abc1", valid: false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			req := &ValidatePasswordRequest{Policy: policy, Password: tc.password}
+			resp, err := New().ValidatePassword(context.Background(), req)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if resp.Valid != tc.valid {
+				t.Errorf("expected: %t, actual: %t", tc.valid, resp.Valid)
+			}
+			if !tc.valid && resp.Violation == "" {
+				t.Error("expected a violation")
+			}
+			if tc.valid && resp.Score < 3 {
+				t.Errorf("expected a score of at least 3, received %d", resp.Score)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+)
+
+// MaxScryptMemory is the most memory in bytes, 128 * r * N, ScryptHasher.Verify accepts from a
+// stored hash, so a hash planted by an attacker can't exhaust the memory of the verifier.
+const MaxScryptMemory = 1 << 30
+
+// maxScryptParallelism is the most p ScryptHasher.Verify accepts from a stored hash.
+const maxScryptParallelism = 16
+
+// ScryptHasher is a Hasher using scrypt as specified by RFC 7914, encoded in the PHC string
+// format, e.g. $scrypt$ln=17,r=8,p=1$<salt>$<hash> with N = 2^ln and the salt and hash in
+// unpadded base64.
+type ScryptHasher struct {
+	// LogN is the base 2 logarithm of the CPU and memory cost N.
+	LogN int
+
+	// BlockSize is r and Parallelism is p.
+	BlockSize   int
+	Parallelism int
+
+	// SaltLength and KeyLength are the sizes in bytes of the random salt and the hash.
+	SaltLength int
+	KeyLength  int
+}
+
+// NewScryptHasher returns a ScryptHasher with the parameters recommended by OWASP, N = 2^17,
+// r = 8, and p = 1, a 16 byte salt, and a 32 byte hash.
+func NewScryptHasher() *ScryptHasher {
+	return &ScryptHasher{LogN: 17, BlockSize: 8, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+}
+
+// Hash returns the PHC string of password with a new random salt.
+func (h *ScryptHasher) Hash(password []byte) (string, error) {
+	if !validScryptParams(h.LogN, h.BlockSize, h.Parallelism) {
+		return "", fmt.Errorf("%w: scrypt parameters must be positive and use at most %d bytes",
+			ErrInvalidConfig, MaxScryptMemory)
+	}
+	if h.SaltLength < 1 || h.SaltLength > maxHashLength || h.KeyLength < 1 || h.KeyLength > maxHashLength {
+		return "", fmt.Errorf("%w: scrypt salt and key lengths must be between 1 and %d", ErrInvalidConfig, maxHashLength)
+	}
+	salt, err := randomBytes(h.SaltLength)
+	if err != nil {
+		return "", err
+	}
+	key := scryptKey(password, salt, 1<<h.LogN, h.BlockSize, h.Parallelism, h.KeyLength)
+	params := fmt.Sprintf("ln=%d,r=%d,p=%d", h.LogN, h.BlockSize, h.Parallelism)
+	return encodePHC("scrypt", params, salt, key), nil
+}
+
+// Verify reports whether password matches the PHC string encoded, which was returned by Hash
+// with any parameters using up to MaxScryptMemory.  It returns ErrMalformedHash if encoded
+// isn't a scrypt PHC string within those limits.
+func (h *ScryptHasher) Verify(password []byte, encoded string) (bool, error) {
+	params, salt, expected, err := decodePHC("scrypt", encoded)
+	if err != nil {
+		return false, err
+	}
+	var logN, r, p int
+	if n, err := fmt.Sscanf(params, "ln=%d,r=%d,p=%d", &logN, &r, &p); err != nil || n != 3 ||
+		params != fmt.Sprintf("ln=%d,r=%d,p=%d", logN, r, p) || !validScryptParams(logN, r, p) {
+		return false, ErrMalformedHash
+	}
+	key := scryptKey(password, salt, 1<<logN, r, p, len(expected))
+	defer Zero(key)
+	return subtle.ConstantTimeCompare(key, expected) == 1, nil
+}
+
+// validScryptParams reports whether the scrypt parameters are positive and within
+// MaxScryptMemory and maxScryptParallelism.
+func validScryptParams(logN, r, p int) bool {
+	return logN >= 1 && logN < 32 && r >= 1 && p >= 1 && p <= maxScryptParallelism &&
+		r <= MaxScryptMemory/128>>logN
+}
+
+// scryptKey returns the scrypt key of password as specified by RFC 7914, for parameters
+// checked with validScryptParams.
+func scryptKey(password, salt []byte, n, r, p, keyLength int) []byte {
+	b := pbkdf2Key(password, salt, 1, p*128*r)
+	defer Zero(b)
+
+	x := make([]uint32, 32*r)
+	y := make([]uint32, 32*r)
+	v := make([]uint32, 32*r*n)
+	for i := 0; i < p; i++ {
+		scryptROMix(b[i*128*r:(i+1)*128*r], x, y, v, n, r)
+	}
+	clear(x)
+	clear(y)
+	clear(v)
+	return pbkdf2Key(password, b, 1, keyLength)
+}
+
+// scryptROMix mixes the block b of 128 * r bytes in place, using x and y of 32 * r words and v
+// of 32 * r * n words as scratch space.
+func scryptROMix(b []byte, x, y, v []uint32, n, r int) {
+	for i := range x {
+		x[i] = binary.LittleEndian.Uint32(b[i*4:])
+	}
+	words := 32 * r
+	for i := 0; i < n; i++ {
+		copy(v[i*words:], x)
+		scryptBlockMix(x, y, r)
+	}
+	for i := 0; i < n; i++ {
+		j := int(x[(2*r-1)*16] & uint32(n-1))
+		for k, w := range v[j*words : (j+1)*words] {
+			x[k] ^= w
+		}
+		scryptBlockMix(x, y, r)
+	}
+	for i, w := range x {
+		binary.LittleEndian.PutUint32(b[i*4:], w)
+	}
+}
+
+// scryptBlockMix mixes the 2 * r blocks of 16 words of b in place, using y as scratch space.
+func scryptBlockMix(b, y []uint32, r int) {
+	var x [16]uint32
+	copy(x[:], b[(2*r-1)*16:])
+	for i := 0; i < 2*r; i++ {
+		for j := range x {
+			x[j] ^= b[i*16+j]
+		}
+		salsa208(&x)
+		// Even blocks go to the first half of the output and odd blocks to the second.
+		copy(y[(i/2+(i%2)*r)*16:], x[:])
+	}
+	copy(b, y)
+}
+
+// salsa208 applies the Salsa20/8 core to the block.
+func salsa208(b *[16]uint32) {
+	x := *b
+	for i := 0; i < 8; i += 2 {
+		x[4] ^= bits.RotateLeft32(x[0]+x[12], 7)
+		x[8] ^= bits.RotateLeft32(x[4]+x[0], 9)
+		x[12] ^= bits.RotateLeft32(x[8]+x[4], 13)
+		x[0] ^= bits.RotateLeft32(x[12]+x[8], 18)
+		x[9] ^= bits.RotateLeft32(x[5]+x[1], 7)
+		x[13] ^= bits.RotateLeft32(x[9]+x[5], 9)
+		x[1] ^= bits.RotateLeft32(x[13]+x[9], 13)
+		x[5] ^= bits.RotateLeft32(x[1]+x[13], 18)
+		x[14] ^= bits.RotateLeft32(x[10]+x[6], 7)
+		x[2] ^= bits.RotateLeft32(x[14]+x[10], 9)
+		x[6] ^= bits.RotateLeft32(x[2]+x[14], 13)
+		x[10] ^= bits.RotateLeft32(x[6]+x[2], 18)
+		x[3] ^= bits.RotateLeft32(x[15]+x[11], 7)
+		x[7] ^= bits.RotateLeft32(x[3]+x[15], 9)
+		x[11] ^= bits.RotateLeft32(x[7]+x[3], 13)
+		x[15] ^= bits.RotateLeft32(x[11]+x[7], 18)
+
+		x[1] ^= bits.RotateLeft32(x[0]+x[3], 7)
+		x[2] ^= bits.RotateLeft32(x[1]+x[0], 9)
+		x[3] ^= bits.RotateLeft32(x[2]+x[1], 13)
+		x[0] ^= bits.RotateLeft32(x[3]+x[2], 18)
+		x[6] ^= bits.RotateLeft32(x[5]+x[4], 7)
+		x[7] ^= bits.RotateLeft32(x[6]+x[5], 9)
+		x[4] ^= bits.RotateLeft32(x[7]+x[6], 13)
+		x[5] ^= bits.RotateLeft32(x[4]+x[7], 18)
+		x[11] ^= bits.RotateLeft32(x[10]+x[9], 7)
+		x[8] ^= bits.RotateLeft32(x[11]+x[10], 9)
+		x[9] ^= bits.RotateLeft32(x[8]+x[11], 13)
+		x[10] ^= bits.RotateLeft32(x[9]+x[8], 18)
+		x[12] ^= bits.RotateLeft32(x[15]+x[14], 7)
+		x[13] ^= bits.RotateLeft32(x[12]+x[15], 9)
+		x[14] ^= bits.RotateLeft32(x[13]+x[12], 13)
+		x[15] ^= bits.RotateLeft32(x[14]+x[13], 18)
+	}
+	for i := range b {
+		b[i] += x[i]
+	}
+}
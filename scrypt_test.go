@@ -0,0 +1,97 @@
+package passwordgen
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestScryptKey(t *testing.T) {
+	t.Parallel()
+
+	// The test vectors of RFC 7914, section 12.
+	for _, tc := range []struct {
+		password, salt string
+		n, r, p        int
+		expected       string
+	}{
+		{"", "", 16, 1, 1, "77d6576238657b203b19ca42c18a0497f16b4844e3074ae8dfdffa3fede21442" +
+			"fcd0069ded0948f8326a753a0fc81f17e8d3e0fb2e0d3628cf35e20c38d18906"},
+		{"password", "NaCl", 1024, 8, 16, "fdbabe1c9d3472007856e7190d01e9fe7c6ad7cbc8237830e77376634b373162" +
+			"2eaf30d92e22a3886ff109279d9830dac727afb94a83ee6d8360cbdfa2cc0640"},
+	} {
+		actual := hex.EncodeToString(scryptKey([]byte(tc.password), []byte(tc.salt), tc.n, tc.r, tc.p, 64))
+		if actual != tc.expected {
+			t.Errorf("expected: %s, actual: %s", tc.expected, actual)
+		}
+	}
+}
+
+func TestScryptHasher(t *testing.T) {
+	t.Parallel()
+
+	t.Run("verify", func(t *testing.T) {
+		t.Parallel()
+
+		hasher := &ScryptHasher{LogN: 10, BlockSize: 8, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+		pass, hash, err := NewGenerator().WithLower().WithDigits().GenerateHashed(20, hasher)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if !strings.HasPrefix(hash, "$scrypt$ln=10,r=8,p=1$") {
+			t.Errorf("expected a scrypt PHC string, received %q", hash)
+		}
+		for password, expected := range map[string]bool{pass: true, pass + "x": false} {
+			ok, err := hasher.Verify([]byte(password), hash)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if ok != expected {
+				t.Errorf("expected: %t, actual: %t for %q", expected, ok, password)
+			}
+		}
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+
+		h := NewScryptHasher()
+		if h.LogN != 17 || h.BlockSize != 8 || h.Parallelism != 1 || h.SaltLength != 16 || h.KeyLength != 32 {
+			t.Errorf("expected the OWASP parameters, received %+v", *h)
+		}
+	})
+
+	t.Run("invalid_parameters", func(t *testing.T) {
+		t.Parallel()
+
+		for _, h := range []*ScryptHasher{
+			{},
+			{LogN: 24, BlockSize: 8, Parallelism: 1, SaltLength: 16, KeyLength: 32},
+			{LogN: 10, BlockSize: 8, Parallelism: 17, SaltLength: 16, KeyLength: 32},
+			{LogN: 10, BlockSize: 8, Parallelism: 1, SaltLength: 0, KeyLength: 32},
+		} {
+			if _, err := h.Hash([]byte("password")); !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("expected: %q, actual: %q for %+v", ErrInvalidConfig, err, *h)
+			}
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		t.Parallel()
+
+		for _, encoded := range []string{
+			"",
+			"$pbkdf2-sha256$i=1000$c2FsdA$aGFzaA",
+			"$scrypt$ln=10,r=8$c2FsdA$aGFzaA",
+			"$scrypt$ln=10,r=8,p=1,x=1$c2FsdA$aGFzaA",
+			"$scrypt$ln=30,r=8,p=1$c2FsdA$aGFzaA",
+			"$scrypt$ln=10,r=8,p=1000$c2FsdA$aGFzaA",
+			"$scrypt$ln=10,r=8,p=1$c2FsdA$",
+		} {
+			if _, err := NewScryptHasher().Verify([]byte("password"), encoded); err != ErrMalformedHash {
+				t.Errorf("expected: %q, actual: %q for %q", ErrMalformedHash, err, encoded)
+			}
+		}
+	})
+}
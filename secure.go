@@ -0,0 +1,94 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"runtime"
+)
+
+// ErrNilHasher is the error returned when a password is generated with a hash
+// but no hashing function is given
+var ErrNilHasher = errors.New("hashing function must not be nil")
+
+// GenerateSecure will generate a password at the specified length as configured and return it
+// as a byte slice, along with a function which overwrites the slice with zeros once the caller
+// is done with the password.
+//
+// The password is assembled directly into the returned slice, which is allocated at its final
+// size so no partial copies are left behind, and is never converted to a string.  The
+// characters are drawn as strings from the generator's pools, and those intermediate strings
+// are immutable and can't be wiped, only the assembled password in the slice can.
+func (g *Generator) GenerateSecure(length int) ([]byte, func(), error) {
+	elements, err := g.generate(length)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	size := 0
+	for _, elm := range elements {
+		size += len(elm)
+	}
+	pass := make([]byte, 0, size)
+	for _, elm := range elements {
+		pass = append(pass, elm...)
+	}
+
+	return pass, func() { Zero(pass) }, nil
+}
+
+// GenerateBytes will generate a password at the specified length as configured and return it
+// as a byte slice, like GenerateSecure.  Call Zero on the slice once the caller is done with
+// the password.
+func (g *Generator) GenerateBytes(length int) ([]byte, error) {
+	pass, _, err := g.GenerateSecure(length)
+	return pass, err
+}
+
+// Zero overwrites b with zeros, to wipe a password or secret from memory once it is no longer
+// needed.
+func Zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+	// Keep b alive until the writes are done so they can't be dropped as dead stores.
+	runtime.KeepAlive(b)
+}
+
+// ConstantTimeEqual reports whether a and b are equal, taking the same time regardless of where
+// they differ.  Use it instead of == when checking a typed password against a generated one,
+// which would leak the length of the matching prefix through its timing.
+//
+// subtle.ConstantTimeCompare returns early when the lengths differ, so the inputs are hashed
+// first and the fixed size digests are compared instead.
+func ConstantTimeEqual(a, b string) bool {
+	hashA, hashB := sha256.Sum256([]byte(a)), sha256.Sum256([]byte(b))
+	return subtle.ConstantTimeCompare(hashA[:], hashB[:]) == 1
+}
+
+// GenerateWithHash will generate a password at the specified length as configured along with
+// the result of hasher over the password's bytes, so the plaintext can be handed to the user
+// while only the hash is stored.  There is no default hasher since a fast hash such as SHA-256
+// is unsuitable for storing passwords, ErrNilHasher is returned if hasher is nil.  The slice
+// given to hasher is zeroed once it returns, so it must not be retained.
+func (g *Generator) GenerateWithHash(length int, hasher func([]byte) []byte) (password string, hash []byte, err error) {
+	if hasher == nil {
+		return "", nil, ErrNilHasher
+	}
+	pass, zeroize, err := g.GenerateSecure(length)
+	if err != nil {
+		return "", nil, err
+	}
+	defer zeroize()
+	return string(pass), hasher(pass), nil
+}
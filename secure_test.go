@@ -0,0 +1,163 @@
+package passwordgen
+
+import (
+	"errors"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestGenerator_GenerateSecure(t *testing.T) {
+	t.Parallel()
+
+	t.Run("zeroize", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().RequireDigits(2).WithLower().WithUpper()
+		pass, zeroize, err := gen.GenerateSecure(16)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if len(pass) != 16 {
+			t.Errorf("Expected password %s to be 16 characters long", pass)
+		}
+		if !containsDigits.Match(pass) {
+			t.Errorf("password %s does not contain digit characters", pass)
+		}
+		zeroize()
+		for i, b := range pass {
+			if b != 0 {
+				t.Errorf("expected byte %d to be zeroed, actual: %x", i, b)
+			}
+		}
+	})
+
+	t.Run("multibyte", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithEmojiSet([]string{"🔥"}).ExactSymbols(2).WithLower()
+		pass, _, err := gen.GenerateSecure(4)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if !utf8.Valid(pass) {
+			t.Errorf("password %q is not valid UTF-8", pass)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		if _, _, err := NewGenerator().GenerateSecure(16); !errors.Is(err, ErrNoCharactersSpecified) {
+			t.Errorf("expected: %q, actual: %q", ErrNoCharactersSpecified, err)
+		}
+	})
+}
+
+func TestGenerator_GenerateBytes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("generates", func(t *testing.T) {
+		t.Parallel()
+		pass, err := NewGenerator().WithLower().ExactDigits(4).GenerateBytes(12)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if len(pass) != 12 || cap(pass) != 12 {
+			t.Errorf("expected: %d, actual: %d (cap %d)", 12, len(pass), cap(pass))
+		}
+		if _, _, digits, _, _ := CountClasses(string(pass)); digits != 4 {
+			t.Errorf("password %s has %d digits, expected 4", pass, digits)
+		}
+		Zero(pass)
+		for _, b := range pass {
+			if b != 0 {
+				t.Fatalf("expected the password to be zeroed, actual: %v", pass)
+			}
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+		if _, err := NewGenerator().GenerateBytes(8); !errors.Is(err, ErrNoCharactersSpecified) {
+			t.Errorf("expected: %q, actual: %q", ErrNoCharactersSpecified, err)
+		}
+	})
+}
+
+func TestZero(t *testing.T) {
+	t.Parallel()
+	b := []byte("secret")
+	Zero(b)
+	if string(b) != "\x00\x00\x00\x00\x00\x00" {
+		t.Errorf("expected the slice to be zeroed, actual: %v", b)
+	}
+	Zero(nil)
+}
+
+func TestConstantTimeEqual(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		a, b     string
+		expected bool
+	}{
+		{"equal", "c0rrect-h0rse", "c0rrect-h0rse", true},
+		{"empty", "", "", true},
+		{"same_length", "c0rrect-h0rse", "c0rrect-h0rsf", false},
+		{"different_length", "c0rrect-h0rse", "c0rrect", false},
+		{"one_empty", "c0rrect", "", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if actual := ConstantTimeEqual(tc.a, tc.b); actual != tc.expected {
+				t.Errorf("expected: %t, actual: %t", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestGenerator_GenerateWithHash(t *testing.T) {
+	t.Parallel()
+
+	t.Run("hashed", func(t *testing.T) {
+		t.Parallel()
+		var hashed []byte
+		reverse := func(b []byte) []byte {
+			hashed = append([]byte(nil), b...)
+			out := make([]byte, len(b))
+			for i := range b {
+				out[len(b)-1-i] = b[i]
+			}
+			return out
+		}
+		pass, hash, err := NewGenerator().WithLower().WithDigits().GenerateWithHash(16, reverse)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if string(hashed) != pass {
+			t.Errorf("expected the hasher to receive %q, received %q", pass, hashed)
+		}
+		for i := range hash {
+			if hash[i] != pass[len(pass)-1-i] {
+				t.Fatalf("expected hash %q to be the reverse of %q", hash, pass)
+			}
+		}
+	})
+
+	t.Run("nil_hasher", func(t *testing.T) {
+		t.Parallel()
+		if _, _, err := NewGenerator().WithLower().GenerateWithHash(16, nil); err != ErrNilHasher {
+			t.Errorf("expected: %q, actual: %q", ErrNilHasher, err)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+		called := false
+		hasher := func(b []byte) []byte { called = true; return b }
+		if _, _, err := NewGenerator().GenerateWithHash(16, hasher); !errors.Is(err, ErrNoCharactersSpecified) {
+			t.Errorf("expected: %q, actual: %q", ErrNoCharactersSpecified, err)
+		}
+		if called {
+			t.Errorf("expected the hasher not to be called when generation fails")
+		}
+	})
+}
@@ -0,0 +1,290 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+// Package server exposes passwordgen over HTTP, for teams which generate secrets in a central
+// service.  The endpoints take a JSON request and respond with the generated secrets:
+//
+//	POST /v1/password    {"policy": {"minLength": 20, "minDigits": 2}, "count": 3}
+//	POST /v1/passphrase  {"words": 5, "separator": "_", "capitalization": "title"}
+//
+// Both respond with {"secrets": ["...", "...", "..."]}.  Errors respond with {"error": "..."}, 400 Bad
+// Request for a malformed request and 422 Unprocessable Entity for a request which can't be
+// generated, e.g. a policy whose requirements don't fit its length.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/kenXengineering/passwordgen"
+	"github.com/kenXengineering/passwordgen/internal/limits"
+)
+
+// DefaultMaxCount is the most secrets generated by a single request, unless set in the
+// Server.
+const DefaultMaxCount = 100
+
+// DefaultMaxLength is the most characters of a password generated by the server, unless set
+// in the Server.  It also caps the maximum length of every policy, so a request can't lift the
+// limit of passwordgen.Generator.WithMaxLength.
+const DefaultMaxLength = 1024
+
+// DefaultMaxWords is the most words of a passphrase generated by the server, unless set in the
+// Server.
+const DefaultMaxWords = 64
+
+// MaxSeparatorLength is the most characters of the separator of a passphrase request.
+const MaxSeparatorLength = limits.MaxSeparatorLength
+
+// maxRequestSize is the most bytes read from the body of a request.
+const maxRequestSize = 1 << 20
+
+var (
+	// ErrInvalidCount is the error returned when a request asks for a non-positive number of
+	// secrets or more than the maximum of the server
+	ErrInvalidCount = errors.New("invalid number of secrets")
+
+	// ErrInvalidLength is the error returned when a request asks for passwords longer than the
+	// maximum of the server, or its policy requires more characters of a kind than the maximum
+	ErrInvalidLength = limits.ErrInvalidLength
+
+	// ErrInvalidWords is the error returned when a request asks for passphrases of more words
+	// than the maximum of the server
+	ErrInvalidWords = limits.ErrInvalidWords
+
+	// ErrInvalidSeparator is the error returned when a request asks for a separator longer than
+	// MaxSeparatorLength
+	ErrInvalidSeparator = limits.ErrInvalidSeparator
+
+	// ErrUnknownCapitalization is the error returned when a passphrase request names a
+	// capitalization which doesn't exist
+	ErrUnknownCapitalization = errors.New("unknown capitalization")
+)
+
+// PasswordRequest is the body of a POST /v1/password request.
+type PasswordRequest struct {
+	// Policy is the policy the passwords satisfy, see passwordgen.Policy.
+	Policy passwordgen.Policy `json:"policy"`
+
+	// Length is the number of characters of the passwords.  If 0, the length is derived from
+	// the policy, see passwordgen.Policy.Generator.
+	Length int `json:"length,omitempty"`
+
+	// Count is the number of passwords, 1 if 0.
+	Count int `json:"count,omitempty"`
+}
+
+// PassphraseRequest is the body of a POST /v1/passphrase request.
+type PassphraseRequest struct {
+	// Words is the number of words of the passphrases.
+	Words int `json:"words"`
+
+	// Separator is placed between the words, passwordgen.DefaultPassphraseSeparator if empty.
+	// It is at most MaxSeparatorLength characters.
+	Separator string `json:"separator,omitempty"`
+
+	// Capitalization is how the words are capitalized, "none", "title", "first", or "random",
+	// "none" if empty.
+	Capitalization string `json:"capitalization,omitempty"`
+
+	// Count is the number of passphrases, 1 if 0.
+	Count int `json:"count,omitempty"`
+}
+
+// Response is the body of a successful response.
+type Response struct {
+	Secrets []string `json:"secrets"`
+}
+
+// ErrorResponse is the body of a failed response.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// capitalizations maps the names accepted in a PassphraseRequest to capitalizations.
+var capitalizations = map[string]passwordgen.Capitalization{
+	"":       passwordgen.CapitalizeNone,
+	"none":   passwordgen.CapitalizeNone,
+	"title":  passwordgen.CapitalizeTitle,
+	"first":  passwordgen.CapitalizeFirst,
+	"random": passwordgen.CapitalizeRandom,
+}
+
+// Server is an http.Handler serving the endpoints of the package.  A Server is safe for
+// concurrent use.
+type Server struct {
+	// MaxCount is the most secrets generated by a single request.  If 0, DefaultMaxCount is
+	// used.
+	MaxCount int
+
+	// MaxLength is the most characters of a password.  If 0, DefaultMaxLength is used.
+	MaxLength int
+
+	// MaxWords is the most words of a passphrase.  If 0, DefaultMaxWords is used.
+	MaxWords int
+
+	mux *http.ServeMux
+}
+
+// New returns a Server with the default limits.
+func New() *Server {
+	s := &Server{}
+	s.mux = http.NewServeMux()
+	s.mux.HandleFunc("/v1/password", post(s.handlePassword))
+	s.mux.HandleFunc("/v1/passphrase", post(s.handlePassphrase))
+	return s
+}
+
+// ServeHTTP serves the request.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// post returns a handler which serves POST requests with h and rejects the other methods with
+// 405 Method Not Allowed.
+func post(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+			return
+		}
+		h(w, r)
+	}
+}
+
+// handlePassword serves POST /v1/password.
+func (s *Server) handlePassword(w http.ResponseWriter, r *http.Request) {
+	var req PasswordRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	count, err := s.count(req.Count)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	maxLength := limit(s.MaxLength, DefaultMaxLength)
+	if err := limits.Password(req.Policy, req.Length, maxLength); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if req.Policy.MaxLength > 0 {
+		maxLength = min(maxLength, req.Policy.MaxLength)
+	}
+
+	gen := req.Policy.Generator().WithMaxLength(maxLength)
+	secrets := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		var secret string
+		if req.Length > 0 {
+			secret, err = gen.Generate(req.Length)
+		} else {
+			secret, err = gen.GenerateDefault()
+		}
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		secrets = append(secrets, secret)
+	}
+	writeJSON(w, http.StatusOK, Response{Secrets: secrets})
+}
+
+// handlePassphrase serves POST /v1/passphrase.
+func (s *Server) handlePassphrase(w http.ResponseWriter, r *http.Request) {
+	var req PassphraseRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	count, err := s.count(req.Count)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := limits.Passphrase(req.Words, req.Separator, limit(s.MaxWords, DefaultMaxWords)); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	capitalization, ok := capitalizations[req.Capitalization]
+	if !ok {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("%w: %q", ErrUnknownCapitalization, req.Capitalization))
+		return
+	}
+
+	gen := passwordgen.NewPassphraseGenerator().Capitalize(capitalization)
+	if req.Separator != "" {
+		gen.WithSeparator(req.Separator)
+	}
+	secrets := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		secret, err := gen.Generate(req.Words)
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+		secrets = append(secrets, secret)
+	}
+	writeJSON(w, http.StatusOK, Response{Secrets: secrets})
+}
+
+// count returns the number of secrets of a request, or an error wrapping ErrInvalidCount.
+func (s *Server) count(count int) (int, error) {
+	if count == 0 {
+		return 1, nil
+	}
+	maxCount := limit(s.MaxCount, DefaultMaxCount)
+	if count < 0 || count > maxCount {
+		return 0, fmt.Errorf("%w: %d, between 1 and %d are allowed", ErrInvalidCount, count, maxCount)
+	}
+	return count, nil
+}
+
+// limit returns the limit set in the Server, or def if it isn't set.
+func limit(set, def int) int {
+	if set <= 0 {
+		return def
+	}
+	return set
+}
+
+// decode decodes the JSON body of the request into v, rejecting unknown fields, and writes a
+// 400 Bad Request response if it can't.  It reports whether v was decoded.
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request: %w", err))
+		return false
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		writeError(w, http.StatusBadRequest, errors.New("invalid request: trailing data after the JSON object"))
+		return false
+	}
+	return true
+}
+
+// writeError writes a response with the status and the message of err.
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, ErrorResponse{Error: err.Error()})
+}
+
+// writeJSON writes a response with the status and v encoded as JSON.  Responses carry secrets,
+// so they are never cached.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
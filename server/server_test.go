@@ -0,0 +1,211 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+// request sends body to the path of a new server and decodes the response into v.
+func request(t *testing.T, s *Server, path, body string, v any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+	if v != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+	}
+	return rec
+}
+
+func TestServer_Password(t *testing.T) {
+	t.Parallel()
+
+	t.Run("policy", func(t *testing.T) {
+		t.Parallel()
+
+		var resp Response
+		rec := request(t, New(), "/v1/password", `{"policy": {"minLength": 20, "minDigits": 4, "forbidden": "#"}, "count": 3}`, &resp)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected: %d, actual: %d", http.StatusOK, rec.Code)
+		}
+		if actual := rec.Header().Get("Cache-Control"); actual != "no-store" {
+			t.Errorf("expected: %q, actual: %q", "no-store", actual)
+		}
+		if len(resp.Secrets) != 3 {
+			t.Fatalf("expected: %d, actual: %d", 3, len(resp.Secrets))
+		}
+		for _, secret := range resp.Secrets {
+			if len(secret) != 20 {
+				t.Errorf("expected: %d, actual: %d", 20, len(secret))
+			}
+			digits := strings.IndexFunc(secret, unicode.IsDigit)
+			if digits < 0 || strings.Contains(secret, "#") {
+				t.Errorf("expected a password satisfying the policy, received %q", secret)
+			}
+		}
+	})
+
+	t.Run("length", func(t *testing.T) {
+		t.Parallel()
+
+		var resp Response
+		rec := request(t, New(), "/v1/password", `{"policy": {}, "length": 32}`, &resp)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected: %d, actual: %d", http.StatusOK, rec.Code)
+		}
+		if len(resp.Secrets) != 1 || len(resp.Secrets[0]) != 32 {
+			t.Errorf("expected a single password of 32 characters, received %q", resp.Secrets)
+		}
+	})
+
+	t.Run("server_limits", func(t *testing.T) {
+		t.Parallel()
+
+		s := New()
+		s.MaxLength = 32
+		var resp Response
+		rec := request(t, s, "/v1/password", `{"policy": {"maxLength": 100000}}`, &resp)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected: %d, actual: %d", http.StatusOK, rec.Code)
+		}
+		if len(resp.Secrets) != 1 || len(resp.Secrets[0]) != 16 {
+			t.Errorf("expected a single password of 16 characters, received %q", resp.Secrets)
+		}
+		rec = request(t, s, "/v1/password", `{"policy": {"minLength": 33}}`, nil)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected: %d, actual: %d", http.StatusBadRequest, rec.Code)
+		}
+	})
+
+	t.Run("unsatisfiable", func(t *testing.T) {
+		t.Parallel()
+
+		var resp ErrorResponse
+		rec := request(t, New(), "/v1/password", `{"policy": {"maxLength": 4, "minDigits": 5}}`, &resp)
+		if rec.Code != http.StatusUnprocessableEntity {
+			t.Errorf("expected: %d, actual: %d", http.StatusUnprocessableEntity, rec.Code)
+		}
+		if resp.Error == "" {
+			t.Error("expected an error message")
+		}
+	})
+}
+
+func TestServer_Passphrase(t *testing.T) {
+	t.Parallel()
+
+	t.Run("options", func(t *testing.T) {
+		t.Parallel()
+
+		var resp Response
+		rec := request(t, New(), "/v1/passphrase", `{"words": 4, "separator": "_", "capitalization": "title", "count": 2}`, &resp)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected: %d, actual: %d", http.StatusOK, rec.Code)
+		}
+		if len(resp.Secrets) != 2 {
+			t.Fatalf("expected: %d, actual: %d", 2, len(resp.Secrets))
+		}
+		for _, secret := range resp.Secrets {
+			words := strings.Split(secret, "_")
+			if len(words) != 4 {
+				t.Errorf("expected: %d, actual: %d", 4, len(words))
+			}
+			for _, word := range words {
+				if word == "" || !unicode.IsUpper([]rune(word)[0]) {
+					t.Errorf("expected capitalized words, received %q", secret)
+				}
+			}
+		}
+	})
+
+	t.Run("unknown_capitalization", func(t *testing.T) {
+		t.Parallel()
+
+		rec := request(t, New(), "/v1/passphrase", `{"words": 4, "capitalization": "shout"}`, nil)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected: %d, actual: %d", http.StatusBadRequest, rec.Code)
+		}
+	})
+
+	t.Run("no_words", func(t *testing.T) {
+		t.Parallel()
+
+		rec := request(t, New(), "/v1/passphrase", `{}`, nil)
+		if rec.Code != http.StatusUnprocessableEntity {
+			t.Errorf("expected: %d, actual: %d", http.StatusUnprocessableEntity, rec.Code)
+		}
+	})
+}
+
+func TestServer_BadRequests(t *testing.T) {
+	t.Parallel()
+
+	s := New()
+	s.MaxCount = 5
+	for name, body := range map[string]string{
+		"malformed":     `{"policy": `,
+		"unknown_field": `{"policy": {}, "size": 3}`,
+		"trailing_data": `{"policy": {}} {}`,
+		"negative":      `{"count": -1}`,
+		"too_many":      `{"count": 6}`,
+		"too_long":      `{"length": 1025}`,
+		"min_too_long":  `{"policy": {"minLength": 10000000, "maxLength": 10000000}, "count": 100}`,
+		"min_overflow":  `{"policy": {"minLower": 2305843009213693952, "minUpper": 2305843009213693952, "minDigits": 2305843009213693952, "minSymbols": 2305843009213693952}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var resp ErrorResponse
+			rec := request(t, s, "/v1/password", body, &resp)
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected: %d, actual: %d", http.StatusBadRequest, rec.Code)
+			}
+			if resp.Error == "" {
+				t.Error("expected an error message")
+			}
+		})
+	}
+
+	t.Run("too_many_words", func(t *testing.T) {
+		t.Parallel()
+
+		var resp ErrorResponse
+		rec := request(t, s, "/v1/passphrase", `{"words": 100000000, "count": 5}`, &resp)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected: %d, actual: %d", http.StatusBadRequest, rec.Code)
+		}
+		if !strings.Contains(resp.Error, ErrInvalidWords.Error()) {
+			t.Errorf("expected: %q, actual: %q", ErrInvalidWords, resp.Error)
+		}
+	})
+
+	t.Run("long_separator", func(t *testing.T) {
+		t.Parallel()
+
+		var resp ErrorResponse
+		body := `{"words": 64, "separator": "` + strings.Repeat("-", MaxSeparatorLength+1) + `", "count": 5}`
+		rec := request(t, s, "/v1/passphrase", body, &resp)
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected: %d, actual: %d", http.StatusBadRequest, rec.Code)
+		}
+		if !strings.Contains(resp.Error, ErrInvalidSeparator.Error()) {
+			t.Errorf("expected: %q, actual: %q", ErrInvalidSeparator, resp.Error)
+		}
+	})
+
+	t.Run("method", func(t *testing.T) {
+		t.Parallel()
+
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/password", nil))
+		if rec.Code != http.StatusMethodNotAllowed {
+			t.Errorf("expected: %d, actual: %d", http.StatusMethodNotAllowed, rec.Code)
+		}
+	})
+}
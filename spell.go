@@ -0,0 +1,83 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import "unicode"
+
+// symbolNames are the spoken names of the symbols in Symbols and ShellSafeSymbols, along with
+// other common ASCII symbols.
+var symbolNames = map[rune]string{
+	'~':  "tilde",
+	'!':  "exclamation mark",
+	'@':  "at sign",
+	'#':  "hash",
+	'$':  "dollar sign",
+	'%':  "percent sign",
+	'^':  "caret",
+	'&':  "ampersand",
+	'*':  "asterisk",
+	'(':  "left parenthesis",
+	')':  "right parenthesis",
+	'_':  "underscore",
+	'+':  "plus sign",
+	'-':  "hyphen",
+	'=':  "equals sign",
+	'{':  "left brace",
+	'}':  "right brace",
+	'[':  "left bracket",
+	']':  "right bracket",
+	',':  "comma",
+	'.':  "period",
+	'/':  "slash",
+	':':  "colon",
+	';':  "semicolon",
+	'?':  "question mark",
+	'<':  "less than sign",
+	'>':  "greater than sign",
+	'|':  "vertical bar",
+	'\\': "backslash",
+	'\'': "apostrophe",
+	'"':  "quotation mark",
+	'`':  "backtick",
+}
+
+// SpellOut describes each character of the password so it can be confirmed verbally, e.g.
+// "lowercase b", "digit 7", or "symbol hash".  The returned slice has one description per
+// character, characters without a known name are described as "symbol" or "character"
+// followed by the character itself.
+func SpellOut(password string) []string {
+	var descriptions []string
+	for _, r := range password {
+		descriptions = append(descriptions, describe(r))
+	}
+	return descriptions
+}
+
+// describe returns the description of a single character for SpellOut.
+func describe(r rune) string {
+	switch {
+	case unicode.IsLower(r):
+		return "lowercase " + string(r)
+	case unicode.IsUpper(r):
+		return "uppercase " + string(r)
+	case unicode.IsDigit(r):
+		return "digit " + string(r)
+	case unicode.IsSpace(r):
+		return "space"
+	}
+	if name, ok := symbolNames[r]; ok {
+		return "symbol " + name
+	}
+	if unicode.IsPunct(r) || unicode.IsSymbol(r) {
+		return "symbol " + string(r)
+	}
+	return "character " + string(r)
+}
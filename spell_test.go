@@ -0,0 +1,51 @@
+package passwordgen
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSpellOut(t *testing.T) {
+	t.Parallel()
+
+	t.Run("descriptions", func(t *testing.T) {
+		t.Parallel()
+		spelled := SpellOut("bB7#-")
+		expected := []string{"lowercase b", "uppercase B", "digit 7", "symbol hash", "symbol hyphen"}
+		if len(spelled) != len(expected) {
+			t.Fatalf("expected: %d descriptions, actual: %d", len(expected), len(spelled))
+		}
+		for i := range expected {
+			if spelled[i] != expected[i] {
+				t.Errorf("expected: %q, actual: %q", expected[i], spelled[i])
+			}
+		}
+	})
+
+	t.Run("default_pools", func(t *testing.T) {
+		t.Parallel()
+		for _, r := range Symbols + ShellSafeSymbols {
+			if description := SpellOut(string(r))[0]; description == "symbol "+string(r) {
+				t.Errorf("expected a name for symbol %c, received %q", r, description)
+			}
+		}
+	})
+
+	t.Run("password_length", func(t *testing.T) {
+		t.Parallel()
+		pass, err := NewGenerator().WithLower().WithUpper().WithDigits().WithSymbols().WithEmojiSet([]string{"🔥"}).Generate(32)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		spelled := SpellOut(pass)
+		if len(spelled) != utf8.RuneCountInString(pass) {
+			t.Errorf("expected: %d descriptions, actual: %d for %s", utf8.RuneCountInString(pass), len(spelled), pass)
+		}
+		for _, description := range spelled {
+			if strings.HasPrefix(description, "character ") {
+				t.Errorf("expected the characters of %s to be described, received %q", pass, description)
+			}
+		}
+	})
+}
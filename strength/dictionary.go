@@ -0,0 +1,79 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package strength
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/kenXengineering/passwordgen"
+)
+
+// commonPasswords is a list of the most common passwords, ranked from most to least common.
+var commonPasswords = strings.Fields(`
+123456 password 12345678 qwerty 123456789 12345 1234 111111 1234567 dragon
+123123 baseball abc123 football monkey letmein 696969 shadow master 666666
+qwertyuiop 123321 mustang 1234567890 michael 654321 superman 1qaz2wsx 7777777 121212
+000000 qazwsx 123qwe killer trustno1 jordan jennifer zxcvbnm asdfgh hunter
+buster soccer harley batman andrew tigger sunshine iloveyou 2000 charlie
+robert thomas hockey ranger daniel starwars klaster 112233 george computer
+michelle jessica pepper 1111 zxcvbn 555555 11111111 131313 freedom 777777
+pass maggie 159753 aaaaaa ginger princess joshua cheese amanda summer
+love ashley nicole chelsea biteme matthew access yankees 987654321 dallas
+austin thunder taylor matrix minecraft william corvette hello martin heather
+secret merlin diamond 1234qwer gfhjkm hammer silver 222222 88888888 anthony
+justin test bailey q1w2e3r4t5 patrick internet scooter orange 11111 golfer
+cookie richard samantha bigdog guitar jackson whatever mickey chicken sparky
+snoopy maverick phoenix camaro peanut morgan welcome falcon cowboy ferrari
+samsung andrea smokey steelers joseph mercedes dakota arsenal eagles melissa
+boomer booboo spider nascar monster tigers yellow xxxxxx 123123123 gateway
+marina diablo bulldog qwer1234 compaq purple hardcore banana junior hannah
+123654 porsche lakers iceman money cowboys 987654 london tennis 999999
+ncc1701 coffee scooby 0000 miller boston q1w2e3r4 brandon yamaha chester
+mother forever johnny edward 333333 oliver redsox player nikita knight
+fender barney midnight please brandy chicago badboy slayer rangers charles
+angel flower rabbit wizard jasper enter rachel chris steven winner
+adidas victoria natasha 1q2w3e4r jasmine winter prince marine ghbdtn
+fishing cocacola casper james 232323 raiders 888888 marlboro gandalf asdfasdf
+crystal 87654321 12344321 golden 8675309 admin changeme passw0rd p@ssw0rd
+qwerty123 password1 password123 abcd1234 iloveyou1 welcome1 letmein1 admin123
+`)
+
+// dictionary is a ranked dictionary, mapping lower case words to their rank from 1.
+type dictionary map[string]int
+
+// newDictionary returns the dictionary of words ranked in order.  Words after the first
+// occurrence keep the first rank.
+func newDictionary(words []string) dictionary {
+	d := make(dictionary, len(words))
+	for i, word := range words {
+		word = strings.ToLower(word)
+		if _, ok := d[word]; !ok && word != "" {
+			d[word] = i + 1
+		}
+	}
+	return d
+}
+
+// englishDictionary returns the dictionary of the words of passwordgen.DefaultWordlist.  The
+// wordlist isn't ordered by frequency, so every word is ranked as the size of the list.
+var englishDictionary = sync.OnceValue(func() dictionary {
+	d := make(dictionary, len(passwordgen.DefaultWordlist))
+	for _, word := range passwordgen.DefaultWordlist {
+		d[word] = len(passwordgen.DefaultWordlist)
+	}
+	return d
+})
+
+// passwordDictionary returns the dictionary of commonPasswords.
+var passwordDictionary = sync.OnceValue(func() dictionary {
+	return newDictionary(commonPasswords)
+})
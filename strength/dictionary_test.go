@@ -0,0 +1,30 @@
+package strength
+
+import (
+	"testing"
+
+	"github.com/kenXengineering/passwordgen"
+)
+
+func TestDictionaries(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ranks", func(t *testing.T) {
+		t.Parallel()
+		d := newDictionary([]string{"First", "second", "first", ""})
+		if d["first"] != 1 || d["second"] != 2 || len(d) != 2 {
+			t.Errorf("unexpected dictionary %v", d)
+		}
+	})
+
+	t.Run("builtin", func(t *testing.T) {
+		t.Parallel()
+		if rank := passwordDictionary()["123456"]; rank != 1 {
+			t.Errorf("expected: %d, actual: %d", 1, rank)
+		}
+		word := passwordgen.DefaultWordlist[0]
+		if rank := englishDictionary()[word]; rank != len(passwordgen.DefaultWordlist) {
+			t.Errorf("expected: %d, actual: %d", len(passwordgen.DefaultWordlist), rank)
+		}
+	})
+}
@@ -0,0 +1,588 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package strength
+
+import (
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Guesses below which a match is counted as these minimums when it is only part of the
+// password, so splits into many tiny matches aren't too cheap.
+const (
+	minSubmatchGuessesSingleChar = 10
+	minSubmatchGuessesMultiChar  = 50
+)
+
+// omnimatch returns every match of every pattern in the password.
+func omnimatch(runes []rune, userInputs []string) []Match {
+	dictionaries := []dictionary{passwordDictionary(), englishDictionary(), newDictionary(userInputs)}
+
+	var matches []Match
+	matches = append(matches, dictionaryMatches(runes, dictionaries)...)
+	matches = append(matches, reversedMatches(runes, dictionaries)...)
+	matches = append(matches, l33tMatches(runes, dictionaries)...)
+	matches = append(matches, spatialMatches(runes)...)
+	matches = append(matches, sequenceMatches(runes)...)
+	matches = append(matches, repeatMatches(runes)...)
+	matches = append(matches, dateMatches(runes)...)
+
+	for i, m := range matches {
+		if m.J-m.I+1 == len(runes) {
+			continue
+		}
+		if m.I == m.J {
+			matches[i].Guesses = max(m.Guesses, minSubmatchGuessesSingleChar)
+		} else {
+			matches[i].Guesses = max(m.Guesses, minSubmatchGuessesMultiChar)
+		}
+	}
+	return matches
+}
+
+// dictionaryMatches returns the parts of the password which are words of the dictionaries,
+// ignoring case.
+func dictionaryMatches(runes []rune, dictionaries []dictionary) []Match {
+	lower := []rune(strings.ToLower(string(runes)))
+	if len(lower) != len(runes) {
+		// Lower casing changed the number of characters, treat the password as is.
+		lower = runes
+	}
+
+	var matches []Match
+	for i := range runes {
+		for j := i; j < len(runes); j++ {
+			word := string(lower[i : j+1])
+			rank := 0
+			for _, d := range dictionaries {
+				if r, ok := d[word]; ok && (rank == 0 || r < rank) {
+					rank = r
+				}
+			}
+			if rank == 0 {
+				continue
+			}
+			token := string(runes[i : j+1])
+			matches = append(matches, Match{
+				Pattern: Dictionary,
+				I:       i,
+				J:       j,
+				Token:   token,
+				Guesses: float64(rank) * uppercaseVariations(token),
+			})
+		}
+	}
+	return matches
+}
+
+// reversedMatches returns the parts of the password which are reversed words of the
+// dictionaries.
+func reversedMatches(runes []rune, dictionaries []dictionary) []Match {
+	reversed := slices.Clone(runes)
+	slices.Reverse(reversed)
+
+	var matches []Match
+	for _, m := range dictionaryMatches(reversed, dictionaries) {
+		i, j := len(runes)-1-m.J, len(runes)-1-m.I
+		token := string(runes[i : j+1])
+		if token == m.Token {
+			// Palindromes already match forwards.
+			continue
+		}
+		matches = append(matches, Match{
+			Pattern:  Dictionary,
+			I:        i,
+			J:        j,
+			Token:    token,
+			Guesses:  m.Guesses * 2,
+			Reversed: true,
+		})
+	}
+	return matches
+}
+
+// l33tTable maps the l33t substitutions to the letters they stand for.
+var l33tTable = map[rune][]rune{
+	'4': {'a'}, '@': {'a'},
+	'8': {'b'},
+	'(': {'c'}, '{': {'c'}, '[': {'c'}, '<': {'c'},
+	'3': {'e'},
+	'6': {'g'}, '9': {'g'},
+	'1': {'i', 'l'}, '!': {'i'}, '|': {'i', 'l'},
+	'7': {'l', 't'},
+	'0': {'o'},
+	'$': {'s'}, '5': {'s'},
+	'+': {'t'},
+	'%': {'x'},
+	'2': {'z'},
+}
+
+// maxL33tSubstitutions is the most combinations of l33t substitutions tried on a password.
+const maxL33tSubstitutions = 64
+
+// l33tMatches returns the parts of the password which are words of the dictionaries with l33t
+// substitutions, such as p4ssw0rd.
+func l33tMatches(runes []rune, dictionaries []dictionary) []Match {
+	var present []rune
+	for _, r := range runes {
+		if _, ok := l33tTable[r]; ok && !slices.Contains(present, r) {
+			present = append(present, r)
+		}
+	}
+	if len(present) == 0 {
+		return nil
+	}
+
+	// Every substitution maps each l33t character present to one of its letters.
+	substitutions := []map[rune]rune{{}}
+	for _, r := range present {
+		var next []map[rune]rune
+		for _, sub := range substitutions {
+			for _, letter := range l33tTable[r] {
+				if len(next) == maxL33tSubstitutions {
+					break
+				}
+				extended := make(map[rune]rune, len(sub)+1)
+				for k, v := range sub {
+					extended[k] = v
+				}
+				extended[r] = letter
+				next = append(next, extended)
+			}
+		}
+		substitutions = next
+	}
+
+	var matches []Match
+	seen := map[[2]int]bool{}
+	for _, sub := range substitutions {
+		translated := make([]rune, len(runes))
+		for i, r := range runes {
+			if letter, ok := sub[r]; ok {
+				translated[i] = letter
+			} else {
+				translated[i] = r
+			}
+		}
+		for _, m := range dictionaryMatches(translated, dictionaries) {
+			token := string(runes[m.I : m.J+1])
+			used := map[rune]rune{}
+			for _, r := range []rune(token) {
+				if letter, ok := sub[r]; ok {
+					used[r] = letter
+				}
+			}
+			// Single character matches such as 1 for i are too short to be l33t.
+			if len(used) == 0 || m.I == m.J || seen[[2]int{m.I, m.J}] {
+				continue
+			}
+			seen[[2]int{m.I, m.J}] = true
+			matches = append(matches, Match{
+				Pattern: Dictionary,
+				I:       m.I,
+				J:       m.J,
+				Token:   token,
+				Guesses: m.Guesses * l33tVariations(token, used),
+				L33t:    true,
+			})
+		}
+	}
+	return matches
+}
+
+// uppercaseVariations returns the number of ways the capitalization of token could have been
+// guessed.  Capitalizing the first letter, the last letter, or every letter are the common
+// cases.
+func uppercaseVariations(token string) float64 {
+	upper, lower := 0, 0
+	for _, r := range token {
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		}
+	}
+	if upper == 0 {
+		return 1
+	}
+	runes := []rune(token)
+	firstOnly := unicode.IsUpper(runes[0]) && upper == 1
+	lastOnly := unicode.IsUpper(runes[len(runes)-1]) && upper == 1
+	if firstOnly || lastOnly || lower == 0 {
+		return 2
+	}
+	return variations(upper, lower)
+}
+
+// l33tVariations returns the number of ways the l33t substitutions of token could have been
+// guessed, where used maps the substituted characters to their letters.
+func l33tVariations(token string, used map[rune]rune) float64 {
+	total := 1.0
+	lower := strings.ToLower(token)
+	for subbed, letter := range used {
+		s, u := strings.Count(token, string(subbed)), strings.Count(lower, string(letter))
+		if u == 0 {
+			// Every occurrence of the letter is substituted.
+			total *= 2
+			continue
+		}
+		total *= variations(s, u)
+	}
+	return total
+}
+
+// variations returns the number of ways of picking between 1 and min(a, b) of a+b items.
+func variations(a, b int) float64 {
+	total := 0.0
+	for i := 1; i <= min(a, b); i++ {
+		total += binomial(a+b, i)
+	}
+	return max(total, 1)
+}
+
+// binomial returns n choose k.
+func binomial(n, k int) float64 {
+	result := 1.0
+	for i := 1; i <= k; i++ {
+		result = result * float64(n-k+i) / float64(i)
+	}
+	return result
+}
+
+// qwertyRows is the US QWERTY layout, unshifted and shifted, from the top row down.
+var qwertyRows = [][2]string{
+	{"`1234567890-=", "~!@#$%^&*()_+"},
+	{"qwertyuiop[]\\", "QWERTYUIOP{}|"},
+	{"asdfghjkl;'", "ASDFGHJKL:\""},
+	{"zxcvbnm,./", "ZXCVBNM<>?"},
+}
+
+// qwertyBelow is, for each row, the offsets from a key's index to the indexes of the keys
+// diagonally below it in the next row.  The rows are staggered, e.g. q sits below 1 and 2.
+var qwertyBelow = [][]int{{-2, -1}, {-1, 0}, {-1, 0}}
+
+// key is the position of a key on the keyboard.
+type key struct {
+	row, col int
+	shifted  bool
+}
+
+// qwertyKeys maps every character of the layout to its key.
+var qwertyKeys = func() map[rune]key {
+	keys := map[rune]key{}
+	for row, layers := range qwertyRows {
+		for layer, chars := range layers {
+			for col, r := range []rune(chars) {
+				keys[r] = key{row: row, col: col, shifted: layer == 1}
+			}
+		}
+	}
+	return keys
+}()
+
+// direction returns the direction from a to b, and false if they aren't adjacent keys.
+func direction(a, b key) ([2]int, bool) {
+	dr, dc := b.row-a.row, b.col-a.col
+	switch {
+	case dr == 0 && (dc == 1 || dc == -1):
+		return [2]int{dr, dc}, true
+	case dr == 1 && a.row < len(qwertyBelow) && slices.Contains(qwertyBelow[a.row], dc):
+		return [2]int{dr, dc}, true
+	case dr == -1 && b.row < len(qwertyBelow) && slices.Contains(qwertyBelow[b.row], -dc):
+		return [2]int{dr, dc}, true
+	}
+	return [2]int{}, false
+}
+
+// keyboardStartingPositions and keyboardAverageDegree describe the layout for the guesses of
+// a walk: the number of characters it can start with and the average number of neighbours of
+// a key.
+var keyboardStartingPositions, keyboardAverageDegree = func() (float64, float64) {
+	neighbours, keys := 0, 0
+	for _, a := range qwertyKeys {
+		if a.shifted {
+			continue
+		}
+		keys++
+		for _, b := range qwertyKeys {
+			if _, ok := direction(a, b); ok && !b.shifted {
+				neighbours++
+			}
+		}
+	}
+	return float64(len(qwertyKeys)), float64(neighbours) / float64(keys)
+}()
+
+// spatialMatches returns the walks of 3 or more adjacent keys in the password.
+func spatialMatches(runes []rune) []Match {
+	var matches []Match
+	for i := 0; i < len(runes)-2; {
+		j, turns := i, 0
+		var last [2]int
+		for j+1 < len(runes) {
+			a, okA := qwertyKeys[runes[j]]
+			b, okB := qwertyKeys[runes[j+1]]
+			if !okA || !okB {
+				break
+			}
+			dir, ok := direction(a, b)
+			if !ok {
+				break
+			}
+			if j == i || dir != last {
+				turns++
+			}
+			last = dir
+			j++
+		}
+		if j-i < 2 {
+			i++
+			continue
+		}
+		token := string(runes[i : j+1])
+		matches = append(matches, Match{
+			Pattern: Spatial,
+			I:       i,
+			J:       j,
+			Token:   token,
+			Guesses: spatialGuesses(runes[i:j+1], turns),
+		})
+		i = j + 1
+	}
+	return matches
+}
+
+// spatialGuesses returns the guesses of a walk with the given number of turns: every walk up
+// to its length with up to its number of turns, doubled by the shifted keys.
+func spatialGuesses(walk []rune, turns int) float64 {
+	guesses := 0.0
+	for i := 2; i <= len(walk); i++ {
+		for j := 1; j <= min(turns, i-1); j++ {
+			guesses += binomial(i-1, j-1) * keyboardStartingPositions * math.Pow(keyboardAverageDegree, float64(j))
+		}
+	}
+	shifted := 0
+	for _, r := range walk {
+		if qwertyKeys[r].shifted {
+			shifted++
+		}
+	}
+	if shifted > 0 {
+		if shifted == len(walk) {
+			guesses *= 2
+		} else {
+			guesses *= variations(shifted, len(walk)-shifted)
+		}
+	}
+	return guesses
+}
+
+// maxSequenceStep is the largest step between the characters of a sequence.
+const maxSequenceStep = 5
+
+// sequenceMatches returns the runs of 3 or more letters of the same case or digits with a
+// constant step between them, such as abc, 2468, or ZYX.
+func sequenceMatches(runes []rune) []Match {
+	var matches []Match
+	for i := 0; i < len(runes)-2; {
+		step := int(runes[i+1]) - int(runes[i])
+		j := i + 1
+		for j+1 < len(runes) && int(runes[j+1])-int(runes[j]) == step {
+			j++
+		}
+		if j-i < 2 || step == 0 || step > maxSequenceStep || step < -maxSequenceStep || !sameClass(runes[i:j+1]) {
+			i++
+			continue
+		}
+		token := string(runes[i : j+1])
+		matches = append(matches, Match{
+			Pattern: Sequence,
+			I:       i,
+			J:       j,
+			Token:   token,
+			Guesses: sequenceGuesses(runes[i:j+1], step > 0),
+		})
+		i = j
+	}
+	return matches
+}
+
+// sameClass returns whether the runes are all lower case letters, upper case letters, or
+// digits.
+func sameClass(runes []rune) bool {
+	for _, class := range []func(rune) bool{isLowerASCII, isUpperASCII, isDigitASCII} {
+		if !slices.ContainsFunc(runes, func(r rune) bool { return !class(r) }) {
+			return true
+		}
+	}
+	return false
+}
+
+func isLowerASCII(r rune) bool { return r >= 'a' && r <= 'z' }
+func isUpperASCII(r rune) bool { return r >= 'A' && r <= 'Z' }
+func isDigitASCII(r rune) bool { return r >= '0' && r <= '9' }
+
+// sequenceGuesses returns the guesses of a sequence, which are few for the obvious starts.
+func sequenceGuesses(sequence []rune, ascending bool) float64 {
+	var base float64
+	switch first := sequence[0]; {
+	case strings.ContainsRune("aAzZ019", first):
+		base = 4
+	case isDigitASCII(first):
+		base = 10
+	default:
+		base = 26
+	}
+	if !ascending {
+		base *= 2
+	}
+	return base * float64(len(sequence))
+}
+
+// repeatMatches returns the parts of the password which repeat a string 2 or more times, such
+// as aaa or abcabc.  Each repeat takes the guesses of its base string times the repetitions.
+func repeatMatches(runes []rune) []Match {
+	var matches []Match
+	for i := 0; i < len(runes)-1; {
+		bestBase, bestCount := 0, 0
+		for base := 1; i+2*base <= len(runes); base++ {
+			count := 1
+			for i+(count+1)*base <= len(runes) &&
+				slices.Equal(runes[i:i+base], runes[i+count*base:i+(count+1)*base]) {
+				count++
+			}
+			if count > 1 && base*count > bestBase*bestCount {
+				bestBase, bestCount = base, count
+			}
+		}
+		if bestCount == 0 {
+			i++
+			continue
+		}
+		j := i + bestBase*bestCount - 1
+		base := Estimate(string(runes[i : i+bestBase]))
+		matches = append(matches, Match{
+			Pattern: Repeat,
+			I:       i,
+			J:       j,
+			Token:   string(runes[i : j+1]),
+			Guesses: base.Guesses * float64(bestCount),
+		})
+		i = j + 1
+	}
+	return matches
+}
+
+// minYearSpace is the fewest years a guesser is assumed to try around the current year.
+const minYearSpace = 20
+
+// dateSplits are the ways of splitting dates without separators of each length into 3 parts,
+// as the indexes where the second and third parts start.
+var dateSplits = map[int][][2]int{
+	4: {{1, 2}, {2, 3}},
+	5: {{1, 3}, {2, 3}},
+	6: {{1, 2}, {2, 4}, {4, 5}},
+	7: {{1, 3}, {2, 3}, {4, 5}, {4, 6}},
+	8: {{2, 4}, {4, 6}},
+}
+
+// separatedDate matches dates with the same separator between the day, month, and year.
+var separatedDate = regexp.MustCompile(`^(\d{1,4})([\s/\\_.-])(\d{1,2})([\s/\\_.-])(\d{1,4})$`)
+
+// recentYear matches years from 1900 to 2099.
+var recentYear = regexp.MustCompile(`^(19|20)\d\d$`)
+
+// dateMatches returns the parts of the password which are dates or recent years.
+func dateMatches(runes []rune) []Match {
+	var matches []Match
+	for i := range runes {
+		for j := i + 3; j < min(len(runes), i+10); j++ {
+			token := string(runes[i : j+1])
+			if recentYear.MatchString(token) {
+				year, _ := strconv.Atoi(token)
+				matches = append(matches, Match{Pattern: Date, I: i, J: j, Token: token, Guesses: yearSpace(year)})
+			}
+
+			year, ok, separated := 0, false, false
+			if parts := separatedDate.FindStringSubmatch(token); parts != nil && parts[2] == parts[4] {
+				year, ok = parseDate(atoi(parts[1]), atoi(parts[3]), atoi(parts[5]))
+				separated = true
+			} else if isDigits(token) {
+				for _, split := range dateSplits[len(token)] {
+					if year, ok = parseDate(atoi(token[:split[0]]), atoi(token[split[0]:split[1]]), atoi(token[split[1]:])); ok {
+						break
+					}
+				}
+			}
+			if !ok {
+				continue
+			}
+			guesses := yearSpace(year) * 365
+			if separated {
+				guesses *= 4
+			}
+			matches = append(matches, Match{Pattern: Date, I: i, J: j, Token: token, Guesses: guesses})
+		}
+	}
+	return matches
+}
+
+// parseDate returns the year of a date made of the given parts in any of the common orders
+// with the year first or last, and false if they don't make a valid date.
+func parseDate(a, b, c int) (int, bool) {
+	if b < 1 || b > 31 {
+		return 0, false
+	}
+	for _, candidate := range [][3]int{{c, a, b}, {a, b, c}} {
+		year, x, y := candidate[0], candidate[1], candidate[2]
+		if year > 2050 || (year >= 100 && year < 1000) {
+			continue
+		}
+		if (x < 1 || x > 31 || y < 1 || y > 12) && (y < 1 || y > 31 || x < 1 || x > 12) {
+			continue
+		}
+		switch {
+		case year >= 1000:
+			return year, true
+		case year < 50:
+			return 2000 + year, true
+		default:
+			return 1900 + year, true
+		}
+	}
+	return 0, false
+}
+
+// yearSpace returns the number of years a guesser tries to reach year.
+func yearSpace(year int) float64 {
+	distance := year - time.Now().Year()
+	if distance < 0 {
+		distance = -distance
+	}
+	return float64(max(distance, minYearSpace))
+}
+
+// atoi returns the number s is made of.
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// isDigits returns whether s is made of ASCII digits only.
+func isDigits(s string) bool {
+	return s != "" && !strings.ContainsFunc(s, func(r rune) bool { return !isDigitASCII(r) })
+}
@@ -0,0 +1,153 @@
+package strength
+
+import (
+	"testing"
+)
+
+// findMatch returns the match of pattern covering the whole token, if any.
+func findMatch(matches []Match, pattern Pattern, token string) (Match, bool) {
+	for _, m := range matches {
+		if m.Pattern == pattern && m.Token == token {
+			return m, true
+		}
+	}
+	return Match{}, false
+}
+
+func TestDictionaryMatches(t *testing.T) {
+	t.Parallel()
+
+	dictionaries := []dictionary{newDictionary([]string{"password", "dragon"})}
+	for _, tc := range []struct {
+		password string
+		token    string
+		guesses  float64
+		reversed bool
+		l33t     bool
+	}{
+		{"password", "password", 1, false, false},
+		{"xPassword", "Password", 2, false, false},
+		{"nogard", "nogard", 4, true, false},
+		{"dr4gon!", "dr4gon", 4, false, true},
+		{"p4$$w0rd", "p4$$w0rd", 8, false, true},
+	} {
+		runes := []rune(tc.password)
+		var matches []Match
+		matches = append(matches, dictionaryMatches(runes, dictionaries)...)
+		matches = append(matches, reversedMatches(runes, dictionaries)...)
+		matches = append(matches, l33tMatches(runes, dictionaries)...)
+		m, ok := findMatch(matches, Dictionary, tc.token)
+		if !ok {
+			t.Errorf("expected a dictionary match of %q in %q, actual: %+v", tc.token, tc.password, matches)
+			continue
+		}
+		if m.Guesses != tc.guesses || m.Reversed != tc.reversed || m.L33t != tc.l33t {
+			t.Errorf("unexpected match %+v of %q", m, tc.password)
+		}
+	}
+}
+
+func TestUppercaseVariations(t *testing.T) {
+	t.Parallel()
+	for token, expected := range map[string]float64{"word": 1, "Word": 2, "worD": 2, "WORD": 2, "wOrd": 4, "WoRd": 10, "1234": 1} {
+		if actual := uppercaseVariations(token); actual != expected {
+			t.Errorf("expected %f variations for %q, actual: %f", expected, token, actual)
+		}
+	}
+}
+
+func TestSpatialMatches(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		password string
+		token    string
+	}{
+		{"qwerty", "qwerty"},
+		{"..asdfg..", "asdfg"},
+		{"1qaz2wsx", "2wsx"},
+		{"QWErty", "QWErty"},
+	} {
+		if _, ok := findMatch(spatialMatches([]rune(tc.password)), Spatial, tc.token); !ok {
+			t.Errorf("expected a spatial match of %q in %q", tc.token, tc.password)
+		}
+	}
+	if matches := spatialMatches([]rune("qaplm")); len(matches) != 0 {
+		t.Errorf("expected no spatial matches, actual: %+v", matches)
+	}
+
+	straight, _ := findMatch(spatialMatches([]rune("qwerty")), Spatial, "qwerty")
+	shifted, _ := findMatch(spatialMatches([]rune("QWErty")), Spatial, "QWErty")
+	if shifted.Guesses <= straight.Guesses {
+		t.Errorf("expected shifted keys to increase the guesses, %f <= %f", shifted.Guesses, straight.Guesses)
+	}
+}
+
+func TestSequenceMatches(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		password string
+		token    string
+		guesses  float64
+	}{
+		{"abcd", "abcd", 16},
+		{"x4321", "4321", 80},
+		{"2468", "2468", 40},
+		{"ZYXW", "ZYXW", 32},
+	} {
+		m, ok := findMatch(sequenceMatches([]rune(tc.password)), Sequence, tc.token)
+		if !ok {
+			t.Errorf("expected a sequence match of %q in %q", tc.token, tc.password)
+			continue
+		}
+		if m.Guesses != tc.guesses {
+			t.Errorf("expected: %f, actual: %f", tc.guesses, m.Guesses)
+		}
+	}
+	for _, password := range []string{"ab", "aZ9", "a1b2", "aaaa", "agmt"} {
+		if matches := sequenceMatches([]rune(password)); len(matches) != 0 {
+			t.Errorf("expected no sequence matches in %q, actual: %+v", password, matches)
+		}
+	}
+}
+
+func TestRepeatMatches(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		password string
+		token    string
+	}{
+		{"aaaa", "aaaa"},
+		{"xabcabc", "abcabc"},
+		{"abababab!", "abababab"},
+	} {
+		if _, ok := findMatch(repeatMatches([]rune(tc.password)), Repeat, tc.token); !ok {
+			t.Errorf("expected a repeat match of %q in %q, actual: %+v", tc.token, tc.password, repeatMatches([]rune(tc.password)))
+		}
+	}
+	if matches := repeatMatches([]rune("abcd")); len(matches) != 0 {
+		t.Errorf("expected no repeat matches, actual: %+v", matches)
+	}
+}
+
+func TestDateMatches(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		password string
+		token    string
+	}{
+		{"13/05/1991", "13/05/1991"},
+		{"1991-05-13", "1991-05-13"},
+		{"x130591", "130591"},
+		{"5.13.91", "5.13.91"},
+		{"born1991", "1991"},
+	} {
+		if _, ok := findMatch(dateMatches([]rune(tc.password)), Date, tc.token); !ok {
+			t.Errorf("expected a date match of %q in %q", tc.token, tc.password)
+		}
+	}
+	for _, password := range []string{"13/05-1991", "45/45/1991", "0000"} {
+		if _, ok := findMatch(dateMatches([]rune(password)), Date, password); ok {
+			t.Errorf("expected no date match of %q", password)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+// Package strength estimates how hard a password is to guess, whether it was generated or
+// typed by a user, in the style of zxcvbn.  The password is matched against common passwords,
+// English words, dates, sequences, repeats, and keyboard walks, and the estimate is the number
+// of guesses of the most guessable way of splitting the password into such patterns.
+package strength
+
+import (
+	"math"
+	"time"
+)
+
+// MaxLength is the number of characters of a password which are matched against patterns.
+// The characters after them are counted as brute forced, which keeps the estimate fast for
+// passwords of any length.
+const MaxLength = 100
+
+// minGuessesBeforeGrowingSequence is the number of guesses each additional match costs, so a
+// split into many small matches isn't preferred over fewer longer ones.
+const minGuessesBeforeGrowingSequence = 10000
+
+// bruteforceCardinality is the number of guesses per brute forced character.
+const bruteforceCardinality = 10
+
+// Pattern is the kind of pattern a part of a password matched.
+type Pattern string
+
+const (
+	// Dictionary is a common password, an English word, or one of the user inputs, possibly
+	// reversed, capitalized, or with l33t substitutions such as p4ssw0rd.
+	Dictionary Pattern = "dictionary"
+
+	// Spatial is a walk of adjacent keys on a US QWERTY keyboard, such as qwerty or zxcvbn.
+	Spatial Pattern = "spatial"
+
+	// Sequence is a run of characters with a constant step, such as abcd, 1357, or 9876.
+	Sequence Pattern = "sequence"
+
+	// Repeat is a character or string repeated, such as aaa or abcabc.
+	Repeat Pattern = "repeat"
+
+	// Date is a date such as 13/05/1991 or 130591, or a recent year such as 1991.
+	Date Pattern = "date"
+
+	// Bruteforce is a part of the password which didn't match any pattern.
+	Bruteforce Pattern = "bruteforce"
+)
+
+// Match is a part of a password which matched a pattern.
+type Match struct {
+	// Pattern is the kind of pattern matched.
+	Pattern Pattern
+
+	// I and J are the indexes of the first and last characters of the match.
+	I, J int
+
+	// Token is the matched part of the password.
+	Token string
+
+	// Guesses is the estimated number of guesses to find the token on its own.
+	Guesses float64
+
+	// Reversed and L33t are whether a Dictionary match was reversed or had l33t
+	// substitutions.
+	Reversed bool
+	L33t     bool
+}
+
+// Result is the estimated strength of a password.
+type Result struct {
+	// Score is from 0, too guessable, to 4, very unguessable.
+	Score int
+
+	// Guesses is the estimated number of guesses to find the password.
+	Guesses float64
+
+	// GuessesLog10 is the base 10 logarithm of Guesses, which remains accurate when Guesses
+	// overflows.
+	GuessesLog10 float64
+
+	// Sequence is the most guessable split of the password into matches, in order.
+	Sequence []Match
+}
+
+// CrackTime returns the estimated time to find the password at guessesPerSecond guesses per
+// second, e.g. 1e10 for an offline attack against a fast hash.
+func (r Result) CrackTime(guessesPerSecond float64) time.Duration {
+	seconds := math.Pow(10, r.GuessesLog10-math.Log10(guessesPerSecond))
+	if seconds >= math.MaxInt64/float64(time.Second) {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// Estimate returns the estimated strength of password.  The userInputs are words a guesser
+// would try first, such as the user's name or email address or the name of the site.
+func Estimate(password string, userInputs ...string) Result {
+	runes := []rune(password)
+	extra := 0
+	if len(runes) > MaxLength {
+		extra = len(runes) - MaxLength
+		runes = runes[:MaxLength]
+	}
+
+	sequence, log10 := mostGuessable(runes, omnimatch(runes, userInputs))
+	if extra > 0 {
+		log10 += float64(extra) * math.Log10(bruteforceCardinality)
+		sequence = append(sequence, Match{
+			Pattern: Bruteforce,
+			I:       MaxLength,
+			J:       MaxLength + extra - 1,
+			Token:   string([]rune(password)[MaxLength:]),
+			Guesses: math.Pow(bruteforceCardinality, float64(extra)),
+		})
+	}
+	return Result{
+		Score:        score(log10),
+		Guesses:      math.Pow(10, log10),
+		GuessesLog10: log10,
+		Sequence:     sequence,
+	}
+}
+
+// score returns the score for the base 10 logarithm of the guesses.
+func score(log10 float64) int {
+	// The thresholds of zxcvbn, allowing for a few guesses of rounding.
+	switch {
+	case log10 < math.Log10(1e3+5):
+		return 0
+	case log10 < math.Log10(1e6+5):
+		return 1
+	case log10 < math.Log10(1e8+5):
+		return 2
+	case log10 < math.Log10(1e10+5):
+		return 3
+	}
+	return 4
+}
+
+// mostGuessable returns the split of the password into matches with the fewest guesses, and
+// the base 10 logarithm of the guesses.  Parts which aren't covered by a match are brute
+// forced.  A split into l matches takes l! times the product of the guesses of the matches,
+// as the guesser doesn't know the order of the patterns, plus the guesses of every split into
+// fewer matches.
+func mostGuessable(runes []rune, matches []Match) ([]Match, float64) {
+	n := len(runes)
+	if n == 0 {
+		return nil, 0
+	}
+
+	byEnd := make([][]Match, n)
+	for _, m := range matches {
+		byEnd[m.J] = append(byEnd[m.J], m)
+	}
+	// Brute force every span, splits which brute force two spans in a row are never better
+	// than brute forcing their union.
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			byEnd[j] = append(byEnd[j], Match{
+				Pattern: Bruteforce,
+				I:       i,
+				J:       j,
+				Token:   string(runes[i : j+1]),
+				Guesses: math.Pow(bruteforceCardinality, float64(j-i+1)),
+			})
+		}
+	}
+
+	// best[k][l] is the best split of the first k+1 characters into l matches.
+	best := make([][]split, n)
+	for k := range best {
+		best[k] = make([]split, k+2)
+	}
+	for k := 0; k < n; k++ {
+		for _, m := range byEnd[k] {
+			logGuesses := math.Log10(max(m.Guesses, 1))
+			if m.I == 0 {
+				best[k][1].consider(logGuesses, 1, m)
+				continue
+			}
+			for l, prev := range best[m.I-1] {
+				if prev.ok {
+					best[k][l+1].consider(prev.logPi+logGuesses, l+1, m)
+				}
+			}
+		}
+	}
+
+	bestL, bestLogG := 0, math.Inf(1)
+	for l, s := range best[n-1] {
+		if s.ok && s.logG < bestLogG {
+			bestL, bestLogG = l, s.logG
+		}
+	}
+	sequence := make([]Match, bestL)
+	for k, l := n-1, bestL; l > 0; l-- {
+		m := best[k][l].match
+		sequence[l-1] = m
+		k = m.I - 1
+	}
+	return sequence, bestLogG
+}
+
+// split is the best split found so far of the start of a password into a number of matches.
+type split struct {
+	// logPi is the base 10 logarithm of the product of the guesses of the matches, and logG of
+	// the guesses of the split.
+	logPi float64
+	logG  float64
+
+	// match is the last match of the split.
+	match Match
+	ok    bool
+}
+
+// consider replaces the split with the split of l matches, ending with m, whose product of
+// guesses is 10^logPi if that split takes fewer guesses.
+func (s *split) consider(logPi float64, l int, m Match) {
+	lgamma, _ := math.Lgamma(float64(l + 1))
+	logG := logSum(lgamma/math.Ln10+logPi, float64(l-1)*math.Log10(minGuessesBeforeGrowingSequence))
+	if !s.ok || logG < s.logG {
+		*s = split{logPi: logPi, logG: logG, match: m, ok: true}
+	}
+}
+
+// logSum returns log10(10^a + 10^b) without overflowing.
+func logSum(a, b float64) float64 {
+	hi, lo := max(a, b), min(a, b)
+	return hi + math.Log10(1+math.Pow(10, lo-hi))
+}
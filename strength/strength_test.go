@@ -0,0 +1,112 @@
+package strength
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kenXengineering/passwordgen"
+)
+
+func TestEstimate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("scores", func(t *testing.T) {
+		t.Parallel()
+		for _, tc := range []struct {
+			password string
+			score    int
+		}{
+			{"", 0},
+			{"password", 0},
+			{"P@ssw0rd", 0},
+			{"qwerty", 0},
+			{"abcdef", 0},
+			{"aaaaaa", 0},
+			{"13/05/1991", 1},
+			{"x7#Lq9!vR2@m", 4},
+		} {
+			if result := Estimate(tc.password); result.Score != tc.score {
+				t.Errorf("expected score %d for %q, actual: %d (%+v)", tc.score, tc.password, result.Score, result.Sequence)
+			}
+		}
+	})
+
+	t.Run("generated", func(t *testing.T) {
+		t.Parallel()
+		gen := passwordgen.NewGenerator().WithLower().WithUpper().WithDigits().WithSymbols()
+		for i := 0; i < 20; i++ {
+			pass, err := gen.Generate(16)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if result := Estimate(pass); result.Score != 4 {
+				t.Errorf("expected score 4 for %q, actual: %d (%+v)", pass, result.Score, result.Sequence)
+			}
+		}
+	})
+
+	t.Run("user_inputs", func(t *testing.T) {
+		t.Parallel()
+		without, with := Estimate("kxenx1984"), Estimate("kxenx1984", "Kxenx")
+		if with.GuessesLog10 >= without.GuessesLog10 {
+			t.Errorf("expected the user input to lower the guesses, %f >= %f", with.GuessesLog10, without.GuessesLog10)
+		}
+		if with.Sequence[0].Pattern != Dictionary || with.Sequence[0].Token != "kxenx" {
+			t.Errorf("expected a dictionary match of kxenx, actual: %+v", with.Sequence)
+		}
+	})
+
+	t.Run("sequence_covers_password", func(t *testing.T) {
+		t.Parallel()
+		for _, pass := range []string{"passwordgen123", "correcthorse!", "Tr0ub4dor&3", "ab"} {
+			result := Estimate(pass)
+			next := 0
+			var tokens strings.Builder
+			for _, m := range result.Sequence {
+				if m.I != next {
+					t.Errorf("expected match of %q to start at %d, actual: %d", pass, next, m.I)
+				}
+				next = m.J + 1
+				tokens.WriteString(m.Token)
+			}
+			if tokens.String() != pass {
+				t.Errorf("expected: %q, actual: %q", pass, tokens.String())
+			}
+		}
+	})
+
+	t.Run("long_password", func(t *testing.T) {
+		t.Parallel()
+		pass := strings.Repeat("x7#Lq9!vR2@m", 20)
+		result := Estimate(pass)
+		last := result.Sequence[len(result.Sequence)-1]
+		if last.Pattern != Bruteforce || last.J != len(pass)-1 {
+			t.Errorf("expected the characters after MaxLength to be brute forced, actual: %+v", last)
+		}
+		if math.IsInf(result.GuessesLog10, 0) || result.GuessesLog10 < float64(len(pass)-MaxLength) {
+			t.Errorf("unexpected guesses %f", result.GuessesLog10)
+		}
+	})
+}
+
+func TestResult_CrackTime(t *testing.T) {
+	t.Parallel()
+	result := Result{Guesses: 1e10, GuessesLog10: 10}
+	if crack := result.CrackTime(1e4); crack != 1e6*time.Second {
+		t.Errorf("expected: %s, actual: %s", 1e6*time.Second, crack)
+	}
+	if crack := (Result{GuessesLog10: 100}).CrackTime(1); crack != time.Duration(math.MaxInt64) {
+		t.Errorf("expected: %s, actual: %s", time.Duration(math.MaxInt64), crack)
+	}
+}
+
+func TestScore(t *testing.T) {
+	t.Parallel()
+	for log10, expected := range map[float64]int{0: 0, 3: 0, 3.1: 1, 6.5: 2, 9: 3, 10.1: 4} {
+		if actual := score(log10); actual != expected {
+			t.Errorf("expected score %d for 10^%f guesses, actual: %d", expected, log10, actual)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+)
+
+// CrockfordAlphabet is the Crockford base32 alphabet, which excludes the ambiguous letters
+// I, L, O, and U.
+const CrockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// CrockfordEncoding is the unpadded Crockford base32 encoding, it can be used to decode tokens
+// returned by GenerateCrockfordBase32.
+var CrockfordEncoding = base32.NewEncoding(CrockfordAlphabet).WithPadding(base32.NoPadding)
+
+// ErrInvalidByteCount is the error returned when a token is requested with
+// a non-positive number of random bytes
+var ErrInvalidByteCount = errors.New("number of random bytes must be positive")
+
+// ErrUnknownEncoding is the error returned when a token is requested with
+// an encoding which isn't one of the Encoding constants
+var ErrUnknownEncoding = errors.New("unknown token encoding")
+
+// Encoding is how the random bytes of a token are turned into text.
+type Encoding int
+
+const (
+	// EncodingHex is lower case hexadecimal, 2 characters per byte.
+	EncodingHex Encoding = iota
+
+	// EncodingBase64URL is the unpadded URL and file name safe base64 encoding of RFC 4648,
+	// which can be used in URLs and headers without escaping.
+	EncodingBase64URL
+
+	// EncodingBase32 is the unpadded standard base32 encoding of RFC 4648, which only uses
+	// upper case letters and the digits 2 to 7.
+	EncodingBase32
+
+	// EncodingCrockford is the unpadded Crockford base32 encoding, see CrockfordEncoding.
+	EncodingCrockford
+)
+
+// EncodeToString returns the encoding of b.
+func (e Encoding) EncodeToString(b []byte) (string, error) {
+	switch e {
+	case EncodingHex:
+		return hex.EncodeToString(b), nil
+	case EncodingBase64URL:
+		return base64.RawURLEncoding.EncodeToString(b), nil
+	case EncodingBase32:
+		return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b), nil
+	case EncodingCrockford:
+		return CrockfordEncoding.EncodeToString(b), nil
+	}
+	return "", ErrUnknownEncoding
+}
+
+// GenerateToken will generate a token, such as an API key or session secret, encoding nBytes
+// random bytes with encoding.  The bytes are read from crypto/rand, so 32 bytes give a token
+// with 256 bits of entropy whatever the encoding.
+func GenerateToken(nBytes int, encoding Encoding) (string, error) {
+	b, err := randomBytes(nBytes)
+	if err != nil {
+		return "", err
+	}
+	defer Zero(b)
+	return encoding.EncodeToString(b)
+}
+
+// GenerateAlphanumeric will generate a password at the specified length made of upper case
+// letters and digits only, which is the character set of the QR code alphanumeric mode and
+// encodes more densely than arbitrary text.
+func GenerateAlphanumeric(length int) (string, error) {
+	return NewGenerator().WithUpper().WithDigits().Generate(length)
+}
+
+// GenerateCrockfordBase32 will generate a token encoding nBytes random bytes with the Crockford
+// base32 alphabet.  The bytes are read from crypto/rand.
+func GenerateCrockfordBase32(nBytes int) (string, error) {
+	return GenerateToken(nBytes, EncodingCrockford)
+}
+
+// randomBytes returns n bytes read from crypto/rand.
+func randomBytes(n int) ([]byte, error) {
+	if n <= 0 {
+		return nil, ErrInvalidByteCount
+	}
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
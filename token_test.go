@@ -0,0 +1,132 @@
+package passwordgen
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+)
+
+func TestGenerateAlphanumeric(t *testing.T) {
+	t.Parallel()
+
+	alphanumeric := regexp.MustCompile("^[A-Z0-9]+$")
+	for _, length := range []int{1, 8, 32} {
+		code, err := GenerateAlphanumeric(length)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if !alphanumeric.MatchString(code) {
+			t.Errorf("code %s contains characters outside of A-Z and 0-9", code)
+		}
+		if len(code) != length {
+			t.Errorf("Expected code %s to be %d characters long", code, length)
+		}
+	}
+}
+
+func TestGenerateCrockfordBase32(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round_trip", func(t *testing.T) {
+		t.Parallel()
+		crockford := regexp.MustCompile("^[" + CrockfordAlphabet + "]+$")
+		token, err := GenerateCrockfordBase32(16)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if !crockford.MatchString(token) {
+			t.Errorf("token %s contains characters outside of the Crockford alphabet", token)
+		}
+		decoded, err := CrockfordEncoding.DecodeString(token)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if len(decoded) != 16 {
+			t.Errorf("expected 16 decoded bytes, actual: %d", len(decoded))
+		}
+		if encoded := CrockfordEncoding.EncodeToString(decoded); encoded != token {
+			t.Errorf("expected: %s, actual: %s", token, encoded)
+		}
+	})
+
+	t.Run("known_bytes", func(t *testing.T) {
+		t.Parallel()
+		b := []byte{
+			0x00, 0x44, 0x32, 0x14, 0xc7, 0x42, 0x54, 0xb6, 0x35, 0xcf,
+			0x84, 0x65, 0x3a, 0x56, 0xd7, 0xc6, 0x75, 0xbe, 0x77, 0xdf,
+		}
+		encoded := CrockfordEncoding.EncodeToString(b)
+		if encoded != CrockfordAlphabet {
+			t.Errorf("expected: %s, actual: %s", CrockfordAlphabet, encoded)
+		}
+		decoded, err := CrockfordEncoding.DecodeString(encoded)
+		if err != nil || !bytes.Equal(decoded, b) {
+			t.Errorf("expected: %x, actual: %x (%v)", b, decoded, err)
+		}
+	})
+
+	t.Run("invalid_size", func(t *testing.T) {
+		t.Parallel()
+		if _, err := GenerateCrockfordBase32(0); err != ErrInvalidByteCount {
+			t.Errorf("expected: %q, actual: %q", ErrInvalidByteCount, err)
+		}
+	})
+}
+
+func TestGenerateToken(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		encoding Encoding
+		pattern  string
+		length   int
+	}{
+		{"hex", EncodingHex, "^[0-9a-f]+$", 64},
+		{"base64url", EncodingBase64URL, "^[A-Za-z0-9_-]+$", 43},
+		{"base32", EncodingBase32, "^[A-Z2-7]+$", 52},
+		{"crockford", EncodingCrockford, "^[" + CrockfordAlphabet + "]+$", 52},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			token, err := GenerateToken(32, tc.encoding)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if !regexp.MustCompile(tc.pattern).MatchString(token) {
+				t.Errorf("token %s contains characters outside of %s", token, tc.pattern)
+			}
+			if len(token) != tc.length {
+				t.Errorf("expected: %d, actual: %d", tc.length, len(token))
+			}
+		})
+	}
+
+	t.Run("known_bytes", func(t *testing.T) {
+		t.Parallel()
+		b := []byte("hello")
+		for encoding, expected := range map[Encoding]string{
+			EncodingHex:       "68656c6c6f",
+			EncodingBase64URL: "aGVsbG8",
+			EncodingBase32:    "NBSWY3DP",
+		} {
+			encoded, err := encoding.EncodeToString(b)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			if encoded != expected {
+				t.Errorf("expected: %q, actual: %q", expected, encoded)
+			}
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+		if _, err := GenerateToken(0, EncodingHex); err != ErrInvalidByteCount {
+			t.Errorf("expected: %q, actual: %q", ErrInvalidByteCount, err)
+		}
+		if _, err := GenerateToken(16, Encoding(-1)); err != ErrUnknownEncoding {
+			t.Errorf("expected: %q, actual: %q", ErrUnknownEncoding, err)
+		}
+	})
+}
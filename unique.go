@@ -0,0 +1,167 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInsufficientUniqueCharacters is the error returned when unique characters are required
+// but a pool has fewer characters than need to be drawn from it
+var ErrInsufficientUniqueCharacters = errors.New("not enough distinct characters to avoid repeats")
+
+// RequireUniquePerClass ensures no character repeats within a class of the generated password,
+// e.g. no digit appears twice, while a digit and a letter may still both appear.  Characters
+// are drawn without replacement, so Generate returns ErrInsufficientUniqueCharacters when a
+// pool is smaller than the number of characters drawn from it.
+func (g *Generator) RequireUniquePerClass() *Generator {
+	g.uniquePerClass = true
+	return g
+}
+
+// NoRepeatCharacters ensures every character of the generated password is unique.  Characters
+// drawn into a password are left out of every later draw whatever their class, so this is the
+// same constraint as RequireUniquePerClass and returns ErrInsufficientUniqueCharacters in the
+// same cases.
+func (g *Generator) NoRepeatCharacters() *Generator {
+	return g.RequireUniquePerClass()
+}
+
+// NoConsecutiveDuplicates ensures no character of the generated password is immediately
+// repeated, e.g. "aa" never appears.  Passwords with a repeat are regenerated, which may exceed
+// the maximum number of attempts for long passwords from a handful of characters.  Generate
+// returns ErrInsufficientUniqueCharacters when the pools have a single distinct character and
+// thus can't alternate.
+func (g *Generator) NoConsecutiveDuplicates() *Generator {
+	g.maxRepeat = 1
+	return g
+}
+
+// validateUnique checks that the pools are large enough to draw the plan without repeats.
+func (g *Generator) validateUnique(plan *GenerationPlan) error {
+	if g.maxRepeat > 0 && plan.Length > g.maxRepeat && distinct(g.effectivePool()) < 2 {
+		return fmt.Errorf("%w: a pool of 1 character can't avoid runs longer than %d",
+			ErrInsufficientUniqueCharacters, g.maxRepeat)
+	}
+	if !g.uniquePerClass {
+		return nil
+	}
+
+	// Required characters are drawn from their class alone, the rest share the fill pool.
+	available := distinct(plan.fill)
+	for _, class := range g.classes() {
+		if size := distinct(class.elements); class.require > size {
+			return fmt.Errorf("%w: %d %s characters are required but the pool has %d",
+				ErrInsufficientUniqueCharacters, class.require, class.class, size)
+		}
+		if class.with {
+			available -= class.require
+		}
+	}
+	for _, set := range g.setRequirements() {
+		if size := distinct(set.elements); set.n > size {
+			return fmt.Errorf("%w: %d characters are required from a set of %d",
+				ErrInsufficientUniqueCharacters, set.n, size)
+		}
+	}
+	if drawn := plan.Free + plan.Diversity; drawn > available {
+		return fmt.Errorf("%w: %d characters are drawn from a pool of %d",
+			ErrInsufficientUniqueCharacters, drawn, max(available, 0))
+	}
+	return nil
+}
+
+// distinct returns the number of distinct elements.
+func distinct(elements []string) int {
+	seen := make(map[string]bool, len(elements))
+	for _, elm := range elements {
+		seen[elm] = true
+	}
+	return len(seen)
+}
+
+// picker tracks the elements drawn into a password so RequireUniquePerClass can exclude them
+// from later draws.  The zero value doesn't track anything.
+type picker struct {
+	used map[string]bool
+}
+
+// newPicker returns the picker for a single password.
+func (g *Generator) newPicker() *picker {
+	if !g.uniquePerClass {
+		return &picker{}
+	}
+	return &picker{used: map[string]bool{}}
+}
+
+// available returns the elements of pool which haven't been drawn yet.
+func (p *picker) available(pool []string) ([]string, error) {
+	if p.used == nil {
+		return pool, nil
+	}
+	var elements []string
+	for _, elm := range pool {
+		if !p.used[elm] {
+			elements = append(elements, elm)
+		}
+	}
+	if len(elements) == 0 {
+		return nil, ErrInsufficientUniqueCharacters
+	}
+	return elements, nil
+}
+
+// draw draws an element from the elements of pool which haven't been drawn yet with pick.
+func (p *picker) draw(pool []string, pick func([]string) (string, error)) (string, error) {
+	elements, err := p.available(pool)
+	if err != nil {
+		return "", err
+	}
+	if len(elements) == 0 {
+		return "", ErrEmptyCharacterSet
+	}
+	elm, err := pick(elements)
+	if err != nil {
+		return "", err
+	}
+	if p.used != nil {
+		p.used[elm] = true
+	}
+	return elm, nil
+}
+
+// drawWeighted draws an element from weighted, the fill pool weighted from elements, leaving
+// out the elements which have been drawn already.
+func (p *picker) drawWeighted(g *Generator, elements [4][]string, weighted *weightedPool) (string, error) {
+	if p.used == nil {
+		return weighted.pick(g.reader())
+	}
+	for class, pool := range elements {
+		// Exhausted classes are left empty, the pool skips them.
+		elements[class], _ = p.available(pool)
+	}
+	var charsets [][]string
+	for _, pool := range g.charsetPools() {
+		available, _ := p.available(pool)
+		charsets = append(charsets, available)
+	}
+	weighted = g.weightedFillPool(elements, charsets)
+	if len(weighted.elements) == 0 {
+		return "", ErrInsufficientUniqueCharacters
+	}
+	elm, err := weighted.pick(g.reader())
+	if err != nil {
+		return "", err
+	}
+	p.used[elm] = true
+	return elm, nil
+}
@@ -0,0 +1,135 @@
+package passwordgen
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGenerator_RequireUniquePerClass(t *testing.T) {
+	t.Parallel()
+
+	// duplicates counts the characters of each class which appear more than once.
+	duplicates := func(pass string) map[CharClass]int {
+		seen := map[rune]bool{}
+		counts := map[CharClass]int{}
+		for _, r := range pass {
+			if seen[r] {
+				class, _ := classOf(r)
+				counts[class]++
+			}
+			seen[r] = true
+		}
+		return counts
+	}
+
+	t.Run("no_duplicates", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithUpper().RequireDigits(10).WithSymbols().RequireUniquePerClass()
+		for i := 0; i < 50; i++ {
+			pass, err := gen.Generate(60)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			for class, n := range duplicates(pass) {
+				if n != 0 {
+					t.Errorf("password %s repeats %d %s characters", pass, n, class)
+				}
+			}
+		}
+	})
+
+	t.Run("weighted", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithDigits().WithEnglishLetterFrequency().RequireUniquePerClass()
+		for i := 0; i < 50; i++ {
+			pass, err := gen.Generate(36)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			for class, n := range duplicates(pass) {
+				if n != 0 {
+					t.Errorf("password %s repeats %d %s characters", pass, n, class)
+				}
+			}
+		}
+	})
+
+	t.Run("required_exceeds_pool", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().RequireDigits(11).RequireUniquePerClass()
+		if _, err := gen.Generate(16); !errors.Is(err, ErrInsufficientUniqueCharacters) {
+			t.Errorf("expected: %q, actual: %q", ErrInsufficientUniqueCharacters, err)
+		}
+	})
+
+	t.Run("length_exceeds_pool", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithDigits().RequireUniquePerClass()
+		if _, err := gen.Generate(10); err != nil {
+			t.Errorf("expected no error, received %q", err)
+		}
+		if _, err := gen.Generate(11); !errors.Is(err, ErrInsufficientUniqueCharacters) {
+			t.Errorf("expected: %q, actual: %q", ErrInsufficientUniqueCharacters, err)
+		}
+	})
+}
+
+func TestGenerator_NoRepeatCharacters(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unique", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithDigits().WithCustomSymbols("ab!").WithSymbols().NoRepeatCharacters()
+		for i := 0; i < 20; i++ {
+			pass, err := gen.Generate(36)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			seen := map[rune]bool{}
+			for _, r := range pass {
+				if seen[r] {
+					t.Errorf("password %s repeats %c", pass, r)
+				}
+				seen[r] = true
+			}
+		}
+	})
+
+	t.Run("pool_too_small", func(t *testing.T) {
+		t.Parallel()
+		if _, err := NewGenerator().WithDigits().NoRepeatCharacters().Generate(11); !errors.Is(err, ErrInsufficientUniqueCharacters) {
+			t.Errorf("expected: %q, actual: %q", ErrInsufficientUniqueCharacters, err)
+		}
+	})
+}
+
+func TestGenerator_NoConsecutiveDuplicates(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no_duplicates", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithDigits().NoConsecutiveDuplicates()
+		for i := 0; i < 20; i++ {
+			pass, err := gen.Generate(12)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			for j := 1; j < len(pass); j++ {
+				if pass[j] == pass[j-1] {
+					t.Errorf("password %s repeats %c in a row", pass, pass[j])
+				}
+			}
+		}
+	})
+
+	t.Run("pool_too_small", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithDigits().ExcludeCharacters("123456789").NoConsecutiveDuplicates()
+		if _, err := gen.Generate(2); !errors.Is(err, ErrInsufficientUniqueCharacters) {
+			t.Errorf("expected: %q, actual: %q", ErrInsufficientUniqueCharacters, err)
+		}
+		if pass, err := gen.Generate(1); err != nil || pass != "0" {
+			t.Errorf("expected: %q, actual: %q (%v)", "0", pass, err)
+		}
+	})
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import (
+	"crypto/rand"
+	"io"
+	"math"
+	"math/big"
+	"sort"
+)
+
+// baseWeight is the weight given to every element of a pool that is drawn uniformly.
+const baseWeight = 1000
+
+// classWeightScale scales the weights given to WithClassWeights so they can be split evenly
+// between the elements of a class.
+const classWeightScale = 1 << 20
+
+// MaxClassWeight is the largest weight accepted by WithClassWeights.  The scaled weights of
+// every class add up to less than the largest int64, so the cumulative weights of the fill
+// pool can't overflow.
+const MaxClassWeight = 1 << 20
+
+// weightedFill returns whether the free positions are drawn from the weighted fill pool rather
+// than uniformly.
+func (g *Generator) weightedFill() bool {
+	return g.englishFrequency || g.hasClassWeights || g.balancedClasses || g.hasCaseRatio
+}
+
+// fillElement draws an element for a free position from the fill pool, weighted when the
+// generator has a weighted fill.
+func (g *Generator) fillElement(pools *poolCache) (string, error) {
+	if g.weightedFill() {
+		return pools.weighted.pick(g.reader())
+	}
+	return g.randomElement(pools.fill)
+}
+
+// weightedFillPool returns the fill pool built from the elements of each class, with each class
+// weighted by WithClassWeights or BalancedClasses, the letters split by WithCaseRatio, and
+// letters weighted by English letter frequency when WithEnglishLetterFrequency is set.  Charsets
+// are weighted by their size, or as a class of weight 1 when classes are weighted.
+func (g *Generator) weightedFillPool(elements [4][]string, charsets [][]string) *weightedPool {
+	enabled := [4]bool{g.withLower, g.withUpper, g.withDigits, g.withSymbols}
+	var shares [4]int64
+	for class, with := range enabled {
+		if !with {
+			continue
+		}
+		shares[class] = int64(len(elements[class])) * baseWeight
+		if g.hasClassWeights {
+			shares[class] = int64(g.classWeights[class]) * classWeightScale
+		} else if g.balancedClasses {
+			shares[class] = classWeightScale
+		}
+	}
+	if g.hasCaseRatio && enabled[ClassLower] && enabled[ClassUpper] {
+		letters := shares[ClassLower] + shares[ClassUpper]
+		shares[ClassUpper] = int64(float64(letters) * g.caseRatio)
+		shares[ClassLower] = letters - shares[ClassUpper]
+	}
+
+	var pool weightedPool
+	for class, with := range enabled {
+		if !with {
+			continue
+		}
+		if g.englishFrequency && (CharClass(class) == ClassLower || CharClass(class) == ClassUpper) {
+			pool.addLetters(elements[class], shares[class])
+		} else {
+			pool.addShare(elements[class], shares[class])
+		}
+	}
+	for _, set := range charsets {
+		if len(set) == 0 {
+			continue
+		}
+		share := int64(len(set)) * baseWeight
+		if g.hasClassWeights || g.balancedClasses {
+			share = classWeightScale
+		}
+		pool.addShare(set, share)
+	}
+	return &pool
+}
+
+// weightedPool is a pool of elements which are drawn with a probability proportional to
+// their weight.
+type weightedPool struct {
+	elements   []string
+	cumulative []int64
+}
+
+// addShare adds the elements to the pool, splitting share evenly between them.
+func (p *weightedPool) addShare(elements []string, share int64) {
+	for _, elm := range elements {
+		p.push(elm, share/int64(len(elements)))
+	}
+}
+
+// addLetters adds the elements to the pool weighted by English letter frequency, scaled so
+// the elements have a combined weight of share.  Elements without a known frequency are
+// given the average weight.
+func (p *weightedPool) addLetters(elements []string, share int64) {
+	var known, total int64
+	for _, elm := range elements {
+		if freq, ok := letterFrequency(elm); ok {
+			known++
+			total += freq
+		}
+	}
+	if known == 0 {
+		p.addShare(elements, share)
+		return
+	}
+	average := total / known
+	count := int64(len(elements))
+	for _, elm := range elements {
+		freq, ok := letterFrequency(elm)
+		if !ok {
+			freq = average
+		}
+		p.push(elm, freq*share/(total+average*(count-known)))
+	}
+}
+
+// push appends a single element to the pool.
+func (p *weightedPool) push(elm string, weight int64) {
+	if weight <= 0 {
+		return
+	}
+	var total int64
+	if n := len(p.cumulative); n > 0 {
+		total = p.cumulative[n-1]
+	}
+	p.elements = append(p.elements, elm)
+	p.cumulative = append(p.cumulative, total+weight)
+}
+
+// pick extracts a random element from the pool with randomness read from r.
+func (p *weightedPool) pick(r io.Reader) (string, error) {
+	if len(p.elements) == 0 {
+		return "", ErrNoCharactersSpecified
+	}
+	n, err := rand.Int(r, big.NewInt(p.cumulative[len(p.cumulative)-1]))
+	if err != nil {
+		return "", err
+	}
+	target := n.Int64()
+	idx := sort.Search(len(p.cumulative), func(i int) bool { return p.cumulative[i] > target })
+	return p.elements[idx], nil
+}
+
+// minEntropy returns the min-entropy in bits of a single element drawn from the pool, -log2 of
+// the probability of its most likely element.
+func (p *weightedPool) minEntropy() float64 {
+	if len(p.elements) == 0 {
+		return 0
+	}
+	weights := map[string]int64{}
+	previous, most := int64(0), int64(0)
+	for i, elm := range p.elements {
+		weights[elm] += p.cumulative[i] - previous
+		previous = p.cumulative[i]
+		most = max(most, weights[elm])
+	}
+	return math.Log2(float64(p.cumulative[len(p.cumulative)-1]) / float64(most))
+}
@@ -0,0 +1,135 @@
+package passwordgen
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+// classShares returns the share of each class among the characters of the passwords.
+func classShares(passwords []string) map[CharClass]float64 {
+	counts := map[CharClass]int{}
+	total := 0
+	for _, pass := range passwords {
+		for _, r := range pass {
+			switch {
+			case strings.ContainsRune(LowerLetters, r):
+				counts[ClassLower]++
+			case strings.ContainsRune(UpperLetters, r):
+				counts[ClassUpper]++
+			case strings.ContainsRune(Digits, r):
+				counts[ClassDigit]++
+			case strings.ContainsRune(Symbols, r):
+				counts[ClassSymbol]++
+			}
+			total++
+		}
+	}
+	shares := map[CharClass]float64{}
+	for class, count := range counts {
+		shares[class] = float64(count) / float64(total)
+	}
+	return shares
+}
+
+func TestGenerator_WithClassWeights(t *testing.T) {
+	t.Parallel()
+
+	t.Run("proportions", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().WithLower().WithUpper().WithDigits().WithSymbols().WithClassWeights(6, 0, 3, 1)
+		var passwords []string
+		for i := 0; i < 200; i++ {
+			pass, err := gen.Generate(100)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			passwords = append(passwords, pass)
+		}
+		shares := classShares(passwords)
+		expected := map[CharClass]float64{ClassLower: 0.6, ClassUpper: 0, ClassDigit: 0.3, ClassSymbol: 0.1}
+		for class, share := range expected {
+			if math.Abs(shares[class]-share) > 0.03 {
+				t.Errorf("expected %s characters to be roughly %.0f%% of the password, actual %.2f%%", class, share*100, shares[class]*100)
+			}
+		}
+	})
+
+	t.Run("required_honored", func(t *testing.T) {
+		t.Parallel()
+		gen := NewGenerator().RequireUpper(2).WithLower().WithClassWeights(1, 0, 0, 0)
+		for i := 0; i < 20; i++ {
+			pass, err := gen.Generate(10)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			count := 0
+			for _, match := range containsUpper.FindAllString(pass, -1) {
+				count += len(match)
+			}
+			if count != 2 {
+				t.Errorf("Expected password %s to have exactly 2 upper characters", pass)
+			}
+		}
+	})
+
+	t.Run("invalid_weights", func(t *testing.T) {
+		t.Parallel()
+		for _, weight := range []int{-1, MaxClassWeight + 1, math.MaxInt} {
+			gen := NewGenerator().WithLower().WithDigits().WithClassWeights(weight, 1, 1, 1)
+			if _, err := gen.Generate(10); err != ErrInvalidConfig {
+				t.Errorf("expected: %q, actual: %q", ErrInvalidConfig, err)
+			}
+		}
+		// The largest weights can't overflow the cumulative weights of the pool.
+		gen := NewGenerator().WithLower().WithUpper().WithDigits().WithSymbols().
+			WithClassWeights(MaxClassWeight, MaxClassWeight, MaxClassWeight, MaxClassWeight)
+		pool := gen.pools().weighted
+		for i := 1; i < len(pool.cumulative); i++ {
+			if pool.cumulative[i] <= pool.cumulative[i-1] {
+				t.Fatalf("expected increasing cumulative weights, received %d after %d", pool.cumulative[i], pool.cumulative[i-1])
+			}
+		}
+		if _, err := gen.Generate(10); err != nil {
+			t.Errorf("expected no error, received %q", err)
+		}
+		gen = NewGenerator().WithLower().WithClassWeights(0, 1, 1, 1)
+		gen = NewGenerator().WithLower().WithClassWeights(0, 1, 1, 1)
+		if _, err := gen.Generate(10); !errors.Is(err, ErrNoCharactersSpecified) {
+			t.Errorf("expected: %q, actual: %q", ErrNoCharactersSpecified, err)
+		}
+	})
+}
+
+func TestGenerator_BalancedClasses(t *testing.T) {
+	t.Parallel()
+
+	// spread generates passwords with gen and returns how far the largest class share is from
+	// the smallest.
+	spread := func(t *testing.T, gen *Generator) float64 {
+		var passwords []string
+		for i := 0; i < 200; i++ {
+			pass, err := gen.Generate(100)
+			if err != nil {
+				t.Fatalf("expected no error, received %q", err)
+			}
+			passwords = append(passwords, pass)
+		}
+		shares := classShares(passwords)
+		lowest, highest := 1.0, 0.0
+		for _, class := range []CharClass{ClassLower, ClassUpper, ClassDigit, ClassSymbol} {
+			lowest, highest = math.Min(lowest, shares[class]), math.Max(highest, shares[class])
+		}
+		return highest - lowest
+	}
+
+	uniform := spread(t, NewGenerator().WithLower().WithUpper().WithDigits().WithSymbols())
+	balanced := spread(t, NewGenerator().WithLower().WithUpper().WithDigits().WithSymbols().BalancedClasses())
+	if balanced >= uniform {
+		t.Errorf("expected balanced classes to be closer to equal, spread %.3f balanced vs %.3f uniform", balanced, uniform)
+	}
+	if balanced > 0.03 {
+		t.Errorf("expected every class to be roughly 25%% of the password, spread %.3f", balanced)
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2019 Kenneth Herner <ken@kenxengineering.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and associated documentation files (the "Software"), to deal in the Software without restriction, including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+*/
+
+package passwordgen
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// MinWordlistSize is the smallest number of words accepted by WithWordlistReader, so every word
+// adds at least 10 bits of entropy.
+const MinWordlistSize = 1024
+
+var (
+	// ErrDuplicateWord is the error returned when a wordlist loaded by
+	// WithWordlistReader contains the same word more than once
+	ErrDuplicateWord = errors.New("wordlist contains a duplicate word")
+
+	// ErrWordlistTooSmall is the error returned when a wordlist loaded by
+	// WithWordlistReader has fewer than MinWordlistSize words
+	ErrWordlistTooSmall = errors.New("wordlist has too few words")
+)
+
+//go:embed wordlists/default.txt
+var defaultWordlistFile string
+
+// DefaultWordlist is the wordlist passphrases are drawn from unless set with WithWordlist, made
+// of 1776 common English words of 3 to 8 lower case letters, so each word adds about 10.8 bits
+// of entropy.  The list was written for this package and is licensed with it under the MIT
+// license, see wordlists/README.md.
+var DefaultWordlist = parseWordlist(defaultWordlistFile)
+
+// parseWordlist returns the words of a newline delimited wordlist, ignoring blank lines.  Lines
+// of diceware lists such as the EFF wordlists, e.g. "11111\tabacus", are reduced to their word.
+func parseWordlist(list string) []string {
+	var words []string
+	for _, line := range strings.Split(list, "\n") {
+		word := strings.TrimSpace(line)
+		if fields := strings.Fields(word); len(fields) == 2 && isDiceRoll(fields[0]) {
+			word = fields[1]
+		}
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// isDiceRoll returns whether s is a sequence of die faces numbering a diceware word.
+func isDiceRoll(s string) bool {
+	for _, r := range s {
+		if r < '1' || r > '6' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// WithWordlistReader sets the words passphrases are drawn from to the newline delimited
+// wordlist read from r, e.g. an approved list of an organization.  Blank lines are ignored and
+// diceware numbering is removed.  ErrDuplicateWord is returned if a word is listed twice, as
+// it would be drawn more often than the others, and ErrWordlistTooSmall if the list has fewer
+// than MinWordlistSize words.  The wordlist is left unchanged on error.
+func (p *PassphraseGenerator) WithWordlistReader(r io.Reader) (*PassphraseGenerator, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return p, err
+	}
+	words := parseWordlist(string(data))
+	if err := validateWordlist(words); err != nil {
+		return p, err
+	}
+	p.words = words
+	return p, nil
+}
+
+// WithWordlistFile sets the words passphrases are drawn from to the wordlist in the file at
+// path, see WithWordlistReader.
+func (p *PassphraseGenerator) WithWordlistFile(path string) (*PassphraseGenerator, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return p, err
+	}
+	defer f.Close()
+	return p.WithWordlistReader(f)
+}
+
+// validateWordlist checks the words are distinct and there are enough of them.
+func validateWordlist(words []string) error {
+	seen := make(map[string]bool, len(words))
+	for _, word := range words {
+		if seen[word] {
+			return fmt.Errorf("%w: %q", ErrDuplicateWord, word)
+		}
+		seen[word] = true
+	}
+	if len(words) < MinWordlistSize {
+		return fmt.Errorf("%w: %d words, at least %d are required", ErrWordlistTooSmall, len(words), MinWordlistSize)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package passwordgen
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDefaultWordlist(t *testing.T) {
+	t.Parallel()
+
+	if len(DefaultWordlist) != 1776 {
+		t.Errorf("expected: %d words, actual: %d", 1776, len(DefaultWordlist))
+	}
+	word := regexp.MustCompile("^[a-z]{3,8}$")
+	for _, w := range DefaultWordlist {
+		if !word.MatchString(w) {
+			t.Errorf("word %q isn't 3 to 8 lower case letters", w)
+		}
+	}
+	if !slices.IsSorted(DefaultWordlist) || len(slices.Compact(slices.Clone(DefaultWordlist))) != len(DefaultWordlist) {
+		t.Error("expected the default wordlist to be sorted without duplicates")
+	}
+}
+
+func TestParseWordlist(t *testing.T) {
+	t.Parallel()
+
+	words := parseWordlist("correct\n\n  horse \r\nbattery\n")
+	if expected := []string{"correct", "horse", "battery"}; !slices.Equal(words, expected) {
+		t.Errorf("expected: %q, actual: %q", expected, words)
+	}
+}
+
+func TestParseWordlist_Diceware(t *testing.T) {
+	t.Parallel()
+
+	words := parseWordlist("11111\tabacus\n11112\tabdomen\n66666 zoom\n")
+	if expected := []string{"abacus", "abdomen", "zoom"}; !slices.Equal(words, expected) {
+		t.Errorf("expected: %q, actual: %q", expected, words)
+	}
+
+	// Words which merely contain a space or digits are kept as is.
+	words = parseWordlist("ice cream\n70 watts\n")
+	if expected := []string{"ice cream", "70 watts"}; !slices.Equal(words, expected) {
+		t.Errorf("expected: %q, actual: %q", expected, words)
+	}
+}
+
+func TestPassphraseGenerator_WithWordlistReader(t *testing.T) {
+	t.Parallel()
+
+	words := make([]string, MinWordlistSize)
+	for i := range words {
+		words[i] = fmt.Sprintf("word%d", i)
+	}
+	list := strings.Join(words, "\n")
+
+	t.Run("valid", func(t *testing.T) {
+		t.Parallel()
+		gen, err := NewPassphraseGenerator().WithWordlistReader(strings.NewReader(list))
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		pass, err := gen.Generate(3)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		for _, word := range strings.Split(pass, DefaultPassphraseSeparator) {
+			if !slices.Contains(words, word) {
+				t.Errorf("word %q is not part of the wordlist", word)
+			}
+		}
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		t.Parallel()
+		gen := NewPassphraseGenerator()
+		if _, err := gen.WithWordlistReader(strings.NewReader(list + "\nword7")); !errors.Is(err, ErrDuplicateWord) {
+			t.Errorf("expected: %q, actual: %q", ErrDuplicateWord, err)
+		}
+		if !slices.Equal(gen.words, DefaultWordlist) {
+			t.Error("expected the wordlist to be unchanged")
+		}
+	})
+
+	t.Run("too_small", func(t *testing.T) {
+		t.Parallel()
+		if _, err := NewPassphraseGenerator().WithWordlistReader(strings.NewReader(strings.Join(words[1:], "\n"))); !errors.Is(err, ErrWordlistTooSmall) {
+			t.Errorf("expected: %q, actual: %q", ErrWordlistTooSmall, err)
+		}
+	})
+
+	t.Run("file", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "words.txt")
+		if err := os.WriteFile(path, []byte(list), 0600); err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		gen, err := NewPassphraseGenerator().WithWordlistFile(path)
+		if err != nil {
+			t.Fatalf("expected no error, received %q", err)
+		}
+		if !slices.Equal(gen.words, words) {
+			t.Error("expected the wordlist to be loaded from the file")
+		}
+		if _, err := NewPassphraseGenerator().WithWordlistFile(path + ".missing"); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("expected: %q, actual: %q", os.ErrNotExist, err)
+		}
+	})
+}